@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// Output formats of the -format flag.
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// config holds the flags shared by the commands.
+type config struct {
+	precision int
+	rounding  string
+	policy    string
+	format    string
+	nonFinite bool
+}
+
+// newConfig returns a config whose fields are set by the flags it defines in fs, except for
+// the format, which is defined by formatFlag.
+func newConfig(fs *flag.FlagSet) *config {
+	c := new(config)
+
+	fs.IntVar(&c.precision, "precision", -1, "round results to `n` decimal places, or not at all if negative")
+	fs.StringVar(&c.rounding, "rounding", percent.HalfAwayFromZero.String(),
+		"rounding `mode`: "+names(percent.RoundingModes()))
+	fs.StringVar(&c.policy, "policy", percent.Strict.String(), "range `policy`: "+names(percent.Policies()))
+
+	return c
+}
+
+// formatFlag defines the -format flag of the calculator commands in fs.
+func (c *config) formatFlag(fs *flag.FlagSet) {
+	fs.StringVar(&c.format, "format", formatText, "output `format`: "+formatText+", "+formatJSON+" or "+formatCSV)
+}
+
+// nonFiniteFlag defines the -allow-non-finite flag of the calculator commands in fs.
+func (c *config) nonFiniteFlag(fs *flag.FlagSet) {
+	fs.BoolVar(&c.nonFinite, "allow-non-finite", false,
+		"accept NaN and infinite arguments and results, written as strings in JSON")
+}
+
+// options returns the options selected by c.
+func (c *config) options() ([]percent.Option, error) {
+	mode, ok := percent.LookupRoundingMode(c.rounding)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown rounding mode %q", errUsage, c.rounding)
+	}
+
+	policy, ok := percent.LookupPolicy(c.policy)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown policy %q", errUsage, c.policy)
+	}
+
+	switch c.format {
+	case "", formatText, formatJSON, formatCSV:
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", errUsage, c.format)
+	}
+
+	opts := []percent.Option{
+		percent.WithPrecision(c.precision), percent.WithRounding(mode), percent.WithPolicy(policy),
+	}
+	if c.nonFinite {
+		opts = append(opts, percent.AllowNonFinite())
+	}
+
+	return opts, nil
+}
+
+// write writes the result v of c for the arguments x to w in the format of the config.
+func (c *config) write(w io.Writer, cmd command, x []float64, v float64) error {
+	switch c.format {
+	case formatJSON:
+		return writeJSON(w, cmd, x, v)
+	case formatCSV:
+		return c.writeCSV(w, cmd, x, v)
+	}
+
+	_, err := fmt.Fprintln(w, c.number(v)+cmd.unit)
+
+	return err
+}
+
+// number formats x with the precision of the config, or in its shortest form.
+func (c *config) number(x float64) string {
+	return strconv.FormatFloat(x, 'f', c.precision, 64)
+}
+
+// writeJSON writes the result of cmd as a JSON object with the command, its arguments by name
+// and the result. As JSON has no NaN and infinities, which -allow-non-finite lets through, they
+// are written as the strings "NaN", "+Inf" and "-Inf".
+func writeJSON(w io.Writer, cmd command, x []float64, v float64) error {
+	var b strings.Builder
+
+	b.WriteString(`{"command":`)
+	b.WriteString(strconv.Quote(cmd.name))
+
+	for i, name := range slices.Concat(cmd.args, []string{"result"}) {
+		n := v
+		if i < len(x) {
+			n = x[i]
+		}
+
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			fmt.Fprintf(&b, ",%q:%q", name, strconv.FormatFloat(n, 'g', -1, 64))
+
+			continue
+		}
+
+		data, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&b, ",%q:%s", name, data)
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// writeCSV writes the result of cmd as a CSV header and record with the command, its
+// arguments and the result.
+func (c *config) writeCSV(w io.Writer, cmd command, x []float64, v float64) error {
+	header := slices.Concat([]string{"command"}, cmd.args, []string{"result"})
+	record := []string{cmd.name}
+
+	for _, n := range x {
+		record = append(record, strconv.FormatFloat(n, 'f', -1, 64))
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	_ = cw.Write(append(record, c.number(v)))
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// names returns the names of values as a list, e.g. "a, b or c".
+func names[T fmt.Stringer](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = v.String()
+	}
+
+	return strings.Join(s[:len(s)-1], ", ") + " or " + s[len(s)-1]
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Percent is a command-line calculator for percentages built on package percent.
+//
+// Usage:
+//
+//	percent <command> [flags] <args>
+//
+// The commands are:
+//
+//	of      part total        the percentage part is of total
+//	change  old new           the percentage change from old to new
+//	remain  percent value     the value remaining after a decrease by percent
+//	apply   percent value     percent of value
+//	ratio   percent           percent as a ratio
+//	table   op column...      append a computed column to CSV or TSV input
+//	repl                      evaluate percentage expressions interactively
+//
+// Every command accepts the flags:
+//
+//	-precision n     round results to n decimal places
+//	-rounding mode   rounding mode: half-away-from-zero, half-up, half-even, floor, ceil or truncate
+//	-policy name     range policy: strict, clamp, allow-unbounded or allow-negative
+//	-format name     output format: text, json or csv
+//
+// The table command streams CSV or TSV from standard input to standard output with a column
+// appended, computed from columns selected by name or index: the share of the column total
+// in each row, the change between two columns, or the change from the previous row. It
+// accepts the flags above, except -format, and flags for the delimiter, the header, the name
+// of the column, a fixed total for share, and whether a row with a missing or invalid value
+// fails the command, is skipped or is written with an empty value. Without a fixed total,
+// share reads its input twice, copying a pipe to a temporary file first.
+//
+// The repl command reads expressions of package expr from standard input, one per line, such
+// as "15% of 240" or "what percent is 30 of 120", and writes their results. Results are
+// numbered, so that a later line can refer to the first one as $1, and "name = expr" assigns a
+// variable. "explain expr" also writes the formula of each operation, "set" changes the
+// precision, rounding or policy of the session, and "help" lists the statements. The prompt
+// is only written if standard input is a terminal. It accepts the flags above, except -format.
+//
+// Flags may follow the arguments, and negative numbers are read as arguments. A percent
+// argument may carry a trailing percent sign.
+//
+// The exit status is 0 on success, 2 for invalid usage or input, and a distinct status for
+// each error of package percent, e.g. 3 for a value out of range; see "percent help".
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
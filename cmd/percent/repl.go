@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sentenz/percent/pkg/percent"
+	"github.com/sentenz/percent/pkg/percent/expr"
+)
+
+// replPrompt is the prompt of an interactive session.
+const replPrompt = "> "
+
+// repl is a session of the repl command.
+type repl struct {
+	cfg     *config
+	opts    []percent.Option
+	env     expr.Env
+	results int
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// runREPL runs the repl command with args, reading statements from stdin until its end or
+// "exit", and returns the exit status. The prompt is only written if stdin is a terminal.
+func runREPL(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("repl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	cfg := newConfig(fs)
+
+	pos, err := parseArgs(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(stdout, "%s\n\n", replSynopsis())
+		replHelp(stdout)
+		fmt.Fprintln(stdout, "\nflags:")
+		fs.SetOutput(stdout)
+		fs.PrintDefaults()
+
+		return exitOK
+	}
+
+	if err == nil && len(pos) != 0 {
+		err = fmt.Errorf("%w: repl takes no arguments, got %d", errUsage, len(pos))
+	}
+
+	var opts []percent.Option
+	if err == nil {
+		opts, err = cfg.options()
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "percent repl: %v\n%s\n", err, replSynopsis())
+		fmt.Fprintln(stderr, `Run "percent repl -h" for details.`)
+
+		return exitUsage
+	}
+
+	r := &repl{cfg: cfg, opts: opts, env: expr.Env{}, results: 0, stdout: stdout, stderr: stderr}
+	prompt := isTerminal(stdin)
+	sc := bufio.NewScanner(stdin)
+
+	for {
+		if prompt {
+			fmt.Fprint(stdout, replPrompt)
+		}
+
+		if !sc.Scan() || !r.exec(sc.Text()) {
+			break
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		fmt.Fprintf(stderr, "percent repl: %v\n", err)
+
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// replSynopsis returns the usage line of the repl command.
+func replSynopsis() string {
+	return "usage: percent repl [flags]"
+}
+
+// replHelp writes the statements and commands of a session to w.
+func replHelp(w io.Writer) {
+	for _, line := range []string{
+		"Evaluates percentage expressions such as \"15% of 240\", \"what percent is 30 of 120\",",
+		"\"80 increased by 12.5%\" or \"change from 80 to 100\". Each result is numbered, so the",
+		"first one can be used as $1.",
+		"",
+		"statements:",
+		"  expr                     evaluate expr and number its result",
+		"  name = expr              assign the value of expr to the variable name",
+		"  explain expr             show the formula of each operation of expr, then evaluate it",
+		"  set [setting value]      show the settings, or set precision, rounding or policy",
+		"  vars                     show the variables",
+		"  help                     show this help",
+		"  exit                     end the session",
+	} {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// exec executes a line of input and reports whether the session goes on.
+func (r *repl) exec(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return true
+	}
+
+	// A command name followed by "=" is an assignment to a variable of that name.
+	if len(fields) > 1 && strings.HasPrefix(fields[1], "=") {
+		r.eval(line, 0, false)
+
+		return true
+	}
+
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+
+	switch fields[0] {
+	case "exit", "quit":
+		return false
+	case "help":
+		replHelp(r.stdout)
+	case "vars":
+		r.vars()
+	case "set":
+		r.set(fields[1:])
+	case "explain":
+		if rest == "" {
+			fmt.Fprintln(r.stderr, "error: explain: missing expression")
+
+			break
+		}
+
+		r.eval(line, strings.Index(line, rest), true)
+	default:
+		r.eval(line, 0, false)
+	}
+
+	return true
+}
+
+// eval evaluates the statement at offset in line and writes its result, preceded by the
+// formulas of its operations if explain is set.
+func (r *repl) eval(line string, offset int, explain bool) {
+	n, err := expr.Parse(line[offset:])
+	if err != nil {
+		r.fail(line, offset, err)
+
+		return
+	}
+
+	v, steps, err := expr.Trace(n, r.env, r.opts...)
+	if explain {
+		for _, s := range steps {
+			fmt.Fprintf(r.stdout, "  %s\n", r.formula(s))
+		}
+	}
+
+	if err != nil {
+		r.fail(line, offset, err)
+
+		return
+	}
+
+	if a, ok := n.(*expr.Assignment); ok {
+		fmt.Fprintf(r.stdout, "%s = %s\n", a.Name, r.cfg.number(v))
+
+		return
+	}
+
+	r.results++
+	name := "$" + strconv.Itoa(r.results)
+	r.env[name] = v
+
+	fmt.Fprintf(r.stdout, "%s = %s\n", name, r.cfg.number(v))
+}
+
+// fail writes err for the statement at offset in line. A syntax error points at its position
+// in line.
+func (r *repl) fail(line string, offset int, err error) {
+	if se := (*percent.SyntaxError)(nil); errors.As(err, &se) {
+		col := utf8.RuneCountInString(line[:offset+se.Offset])
+		fmt.Fprintf(r.stderr, "%s%s\n%s^\n", strings.Repeat(" ", len(replPrompt)), line,
+			strings.Repeat(" ", len(replPrompt)+col))
+	}
+
+	fmt.Fprintf(r.stderr, "error: %v\n", err)
+}
+
+// formula returns the formula of the operation of s, e.g. "Of(30, 120) = 30 / 120 * 100 = 25".
+func (r *repl) formula(s expr.Step) string {
+	x, y, v := operand(s.X), operand(s.Y), r.cfg.number(s.Value)
+
+	var f string
+
+	switch n := s.Node.(type) {
+	case *expr.Binary:
+		return x + " " + n.Op.String() + " " + y + " = " + v
+	case *expr.Call:
+		switch n.Func {
+		case expr.FuncPercent:
+			f = y + " * " + x + " / 100"
+		case expr.FuncOf:
+			f = x + " / " + y + " * 100"
+		case expr.FuncChange:
+			f = "(" + y + " - " + x + ") / |" + strconv.FormatFloat(s.X, 'f', -1, 64) + "| * 100"
+		case expr.FuncRemain:
+			f = y + " * (100 - " + x + ") / 100"
+		case expr.FuncIncrease:
+			f = y + " + " + y + " * " + x + " / 100"
+		}
+
+		f = n.Func.String() + "(" + strconv.FormatFloat(s.X, 'f', -1, 64) + ", " +
+			strconv.FormatFloat(s.Y, 'f', -1, 64) + ") = " + f
+	}
+
+	return f + " = " + v
+}
+
+// operand returns x as an operand of a formula, in parentheses if it is negative.
+func operand(x float64) string {
+	s := strconv.FormatFloat(x, 'f', -1, 64)
+	if x < 0 {
+		return "(" + s + ")"
+	}
+
+	return s
+}
+
+// set writes the settings if args is empty, and otherwise sets the setting args[0] to args[1].
+func (r *repl) set(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(r.stdout, "precision %d\nrounding %s\npolicy %s\n", r.cfg.precision, r.cfg.rounding, r.cfg.policy)
+
+		return
+	}
+
+	if len(args) != 2 {
+		fmt.Fprintln(r.stderr, "error: usage: set [precision n | rounding mode | policy name]")
+
+		return
+	}
+
+	cfg := *r.cfg
+
+	switch args[0] {
+	case "precision":
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Fprintf(r.stderr, "error: invalid precision %q\n", args[1])
+
+			return
+		}
+
+		cfg.precision = n
+	case "rounding":
+		cfg.rounding = args[1]
+	case "policy":
+		cfg.policy = args[1]
+	default:
+		fmt.Fprintf(r.stderr, "error: unknown setting %q\n", args[0])
+
+		return
+	}
+
+	opts, err := cfg.options()
+	if err != nil {
+		fmt.Fprintf(r.stderr, "error: %v\n", err)
+
+		return
+	}
+
+	*r.cfg, r.opts = cfg, opts
+}
+
+// vars writes the variables, without the numbered results, in order of their names.
+func (r *repl) vars() {
+	var names []string
+
+	for name := range r.env {
+		if !strings.HasPrefix(name, "$") {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		fmt.Fprintf(r.stdout, "%s = %s\n", name, r.cfg.number(r.env[name]))
+	}
+}
+
+// isTerminal reports whether r is a terminal.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,346 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// Exit statuses of the command. Each error of package percent has its own status.
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+	exitOutOfRange
+	exitDivideByZero
+	exitPartGreaterThanTotal
+	exitNotFinite
+	exitOverflow
+	exitSumMismatch
+)
+
+// errUsage reports invalid arguments or flags.
+var errUsage = errors.New("invalid usage")
+
+// argKind is the kind of an argument of a command.
+type argKind int
+
+const (
+	// argValue is a plain number, such as a value or a total.
+	argValue argKind = iota
+	// argPercent is a percentage, which may also be written in a notation of percent.Parse,
+	// such as "12.5%" or "125‰".
+	argPercent
+)
+
+// command is a subcommand that computes a single result from its arguments.
+type command struct {
+	// name is the name of the command, e.g. "of".
+	name string
+	// args names the arguments of the command.
+	args []string
+	// kinds holds the kind of each argument, in the order of args.
+	kinds []argKind
+	// summary describes the result of the command.
+	summary string
+	// unit is the symbol appended to the result in text output.
+	unit string
+	// calc computes the result from the arguments.
+	calc func(x []float64, opts []percent.Option) (float64, error)
+}
+
+// commands returns the calculator commands.
+func commands() []command {
+	return []command{
+		{
+			name: "of", args: []string{"part", "total"}, kinds: []argKind{argValue, argValue}, unit: "%",
+			summary: "the percentage part is of total",
+			calc: func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.Of(x[0], x[1], opts...)
+			},
+		},
+		{
+			name: "change", args: []string{"old", "new"}, kinds: []argKind{argValue, argValue}, unit: "%",
+			summary: "the percentage change from old to new",
+			calc: func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.Change(x[0], x[1], opts...)
+			},
+		},
+		{
+			name: "remain", args: []string{"percent", "value"}, kinds: []argKind{argPercent, argValue}, unit: "",
+			summary: "the value remaining after a decrease by percent",
+			calc: func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.Remain(x[0], x[1], opts...)
+			},
+		},
+		{
+			name: "apply", args: []string{"percent", "value"}, kinds: []argKind{argPercent, argValue}, unit: "",
+			summary: "percent of value",
+			calc: func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.Percent(x[0], x[1], opts...)
+			},
+		},
+		{
+			name: "ratio", args: []string{"percent"}, kinds: []argKind{argPercent}, unit: "",
+			summary: "percent as a ratio",
+			calc: func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.ToRatio(x[0], opts...)
+			},
+		},
+	}
+}
+
+// run runs the command line args with input from stdin, writes the output to stdout and
+// errors to stderr, and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+
+		return exitOK
+	case "table":
+		return runTable(args[1:], stdin, stdout, stderr)
+	case "repl":
+		return runREPL(args[1:], stdin, stdout, stderr)
+	}
+
+	for _, c := range commands() {
+		if c.name == args[0] {
+			return c.run(args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "percent: unknown command %q\n", args[0])
+	usage(stderr)
+
+	return exitUsage
+}
+
+// run runs c with args and returns the exit status.
+func (c command) run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	cfg := newConfig(fs)
+	cfg.formatFlag(fs)
+	cfg.nonFiniteFlag(fs)
+
+	pos, err := parseArgs(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		c.usage(stdout, fs)
+
+		return exitOK
+	}
+
+	if err == nil && len(pos) != len(c.args) {
+		err = fmt.Errorf("%w: %s takes %d arguments, got %d", errUsage, c.name, len(c.args), len(pos))
+	}
+
+	var x []float64
+	if err == nil {
+		x, err = c.parseNumbers(pos, cfg.nonFinite)
+	}
+
+	var opts []percent.Option
+	if err == nil {
+		opts, err = cfg.options()
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "percent %s: %v\n%s\n", c.name, err, c.synopsis())
+		fmt.Fprintf(stderr, "Run \"percent %s -h\" for details.\n", c.name)
+
+		return exitUsage
+	}
+
+	v, err := c.calc(x, opts)
+	if err == nil {
+		err = cfg.write(stdout, c, x, v)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "percent %s: %v\n", c.name, err)
+	}
+
+	return exitCode(err)
+}
+
+// synopsis returns the usage line of c.
+func (c command) synopsis() string {
+	return "usage: percent " + c.name + " [flags] " + strings.Join(c.args, " ")
+}
+
+// usage writes the usage of c, including its flags, to w.
+func (c command) usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "%s\n\n%s.\n\nflags:\n", c.synopsis(), strings.ToUpper(c.summary[:1])+c.summary[1:])
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+	fs.SetOutput(io.Discard)
+}
+
+// usage writes the usage of the program to w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: percent <command> [flags] <args>")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	for _, c := range commands() {
+		fmt.Fprintf(w, "  %-7s %-16s %s\n", c.name, strings.Join(c.args, " "), c.summary)
+	}
+
+	fmt.Fprintf(w, "  %-7s %-16s %s\n", "table", "op column...", "append a computed column to CSV or TSV input")
+	fmt.Fprintf(w, "  %-7s %-16s %s\n", "repl", "", "evaluate percentage expressions interactively")
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "percent <command> -h" for the flags of a command.`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "exit status:")
+
+	for _, s := range []struct {
+		code int
+		desc string
+	}{
+		{exitOK, "success"},
+		{exitFailure, "failure"},
+		{exitUsage, "invalid usage or input"},
+		{exitOutOfRange, percent.ErrOutOfRange.Error()},
+		{exitDivideByZero, percent.ErrDivideByZero.Error()},
+		{exitPartGreaterThanTotal, percent.ErrPartGreaterThanTotal.Error()},
+		{exitNotFinite, percent.ErrNotFinite.Error()},
+		{exitOverflow, percent.ErrOverflow.Error()},
+		{exitSumMismatch, percent.ErrSumMismatch.Error()},
+	} {
+		fmt.Fprintf(w, "  %d  %s\n", s.code, strings.TrimPrefix(s.desc, "pkg percent: "))
+	}
+}
+
+// exitCode returns the exit status for err.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage), errors.Is(err, errInput), errors.Is(err, percent.ErrSyntax):
+		return exitUsage
+	case errors.Is(err, percent.ErrOutOfRange):
+		return exitOutOfRange
+	case errors.Is(err, percent.ErrDivideByZero):
+		return exitDivideByZero
+	case errors.Is(err, percent.ErrPartGreaterThanTotal):
+		return exitPartGreaterThanTotal
+	case errors.Is(err, percent.ErrNotFinite):
+		return exitNotFinite
+	case errors.Is(err, percent.ErrOverflow):
+		return exitOverflow
+	case errors.Is(err, percent.ErrSumMismatch):
+		return exitSumMismatch
+	}
+
+	return exitFailure
+}
+
+// parseArgs parses the flags in args, which may follow the positional arguments, and returns
+// the positional arguments. Negative numbers are positional arguments, and so is everything
+// after "--".
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var flags, pos []string
+
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+
+		switch {
+		case a == "--":
+			pos = append(pos, args[i+1:]...)
+			i = len(args)
+		case !strings.HasPrefix(a, "-") || a == "-" || isNumber(a):
+			pos = append(pos, a)
+		default:
+			flags = append(flags, a)
+
+			// A flag without "=" takes the next argument as its value, unless it is boolean.
+			name := strings.TrimLeft(a, "-")
+			if f := fs.Lookup(name); f != nil && !isBoolFlag(f) && i+1 < len(args) {
+				i++
+				flags = append(flags, args[i])
+			}
+		}
+	}
+
+	if err := fs.Parse(flags); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	return pos, nil
+}
+
+// isBoolFlag reports whether f is a boolean flag that takes no value.
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+
+	return ok && b.IsBoolFlag()
+}
+
+// isNumber reports whether s is a number, such as a negative argument.
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	return err == nil
+}
+
+// parseNumbers parses the arguments of c, which are NaN or infinite only if nonFinite is set.
+func (c command) parseNumbers(args []string, nonFinite bool) ([]float64, error) {
+	x := make([]float64, len(args))
+
+	for i, a := range args {
+		v, err := parseNumber(a, c.kinds[i], nonFinite)
+
+		var ne *strconv.NumError
+
+		switch {
+		case errors.As(err, &ne):
+			return nil, fmt.Errorf("%w: invalid number %q", errUsage, a)
+		case err != nil:
+			return nil, fmt.Errorf("%w: invalid number %q: %v", errUsage, a, err)
+		}
+
+		x[i] = v
+	}
+
+	return x, nil
+}
+
+// parseNumber parses s as a plain number or, for a percentage, in a notation of percent.Parse,
+// which also checks its range. NaN and infinities are rejected unless nonFinite is set.
+func parseNumber(s string, kind argKind, nonFinite bool) (float64, error) {
+	s = strings.TrimSpace(s)
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil && kind == argPercent {
+		p, err := percent.Parse(s)
+
+		return float64(p), err
+	}
+
+	if err == nil && !nonFinite && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		return 0, percent.ErrNotFinite
+	}
+
+	return v, err
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Set UPDATE_GOLDEN=1 to rewrite the golden files in testdata from the current output.
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	const sales = "region,q1,q2\nnorth,120,150\nsouth,80,60\neast,,40\nwest,200,200\n"
+
+	type in struct {
+		args  []string
+		stdin string
+		// pipe hides the io.Seeker of stdin, as for a pipe.
+		pipe bool
+		// skip is the number of bytes of stdin that were read before the command runs.
+		skip int64
+	}
+
+	tests := []struct {
+		name string
+		in   in
+	}{
+		{name: "of", in: in{args: []string{"of", "50", "200"}}},
+		{name: "of_precision", in: in{args: []string{"of", "1", "3", "--precision", "2"}}},
+		{name: "of_rounding", in: in{args: []string{"of", "2", "3", "-precision=1", "-rounding=floor"}}},
+		{name: "of_json", in: in{args: []string{"of", "--format=json", "1", "8"}}},
+		{name: "of_csv", in: in{args: []string{"of", "1", "8", "--format", "csv"}}},
+		{name: "change", in: in{args: []string{"change", "100", "150"}}},
+		{name: "change_negative", in: in{args: []string{"change", "-100", "-50"}}},
+		{name: "change_json", in: in{args: []string{"change", "80", "60", "--format=json"}}},
+		{name: "remain", in: in{args: []string{"remain", "20%", "80"}}},
+		{name: "apply", in: in{args: []string{"apply", "12.5", "240"}}},
+		{name: "apply_policy", in: in{args: []string{"apply", "150", "240", "--policy", "allow-unbounded"}}},
+		{name: "ratio", in: in{args: []string{"ratio", "12.5%"}}},
+		{name: "ratio_per_mille", in: in{args: []string{"ratio", "125‰"}}},
+		{name: "of_unit_rejected", in: in{args: []string{"of", "25%", "200"}}},
+		{name: "remain_unit_rejected", in: in{args: []string{"remain", "10", "3‰"}}},
+		{name: "ratio_csv", in: in{args: []string{"ratio", "-format=csv", "--", "33"}}},
+		{name: "help", in: in{args: []string{"help"}}},
+		{name: "command_help", in: in{args: []string{"of", "-h"}}},
+		{name: "no_command", in: in{}},
+		{name: "unknown_command", in: in{args: []string{"times", "2", "3"}}},
+		{name: "unknown_flag", in: in{args: []string{"of", "1", "2", "--color"}}},
+		{name: "missing_argument", in: in{args: []string{"of", "1"}}},
+		{name: "invalid_number", in: in{args: []string{"of", "one", "2"}}},
+		{name: "unknown_rounding", in: in{args: []string{"of", "1", "2", "--rounding", "up"}}},
+		{name: "unknown_format", in: in{args: []string{"of", "1", "2", "--format", "xml"}}},
+		{name: "out_of_range", in: in{args: []string{"apply", "150", "240"}}},
+		{name: "divide_by_zero", in: in{args: []string{"of", "1", "0"}}},
+		{name: "part_greater_than_total", in: in{args: []string{"of", "3", "2"}}},
+		{name: "not_finite", in: in{args: []string{"change", "1", "NaN"}}},
+		{name: "not_finite_allowed", in: in{args: []string{"change", "1", "-Inf", "-allow-non-finite"}}},
+		{name: "not_finite_json", in: in{args: []string{"of", "nan", "1", "-allow-non-finite", "-format=json"}}},
+		{name: "not_finite_csv", in: in{args: []string{"change", "1", "+Inf", "-allow-non-finite", "-format=csv"}}},
+		{name: "percent_out_of_range", in: in{args: []string{"apply", "150%", "240"}}},
+		{name: "table_help", in: in{args: []string{"table", "-h"}}},
+		{name: "table_share", in: in{args: []string{"table", "share", "q2", "-precision", "2"}, stdin: sales}},
+		{name: "table_share_pipe", in: in{args: []string{"table", "share", "q1"}, stdin: sales, pipe: true}},
+		{name: "table_share_offset", in: in{args: []string{"table", "share", "q1"}, stdin: "skipped\n" + sales, skip: 8}},
+		{
+			name: "table_share_compensated",
+			in:   in{args: []string{"table", "share", "v"}, stdin: "v\n" + strings.Repeat("0.1\n", 10)},
+		},
+		{name: "table_share_total", in: in{args: []string{"table", "-total", "1000", "share", "q2"}, stdin: sales}},
+		{name: "table_change", in: in{args: []string{"table", "change", "q1", "q2", "-name", "growth", "-on-error", "empty"}, stdin: sales}},
+		{name: "table_rowchange", in: in{args: []string{"table", "rowchange", "2", "--on-error=empty", "-precision=2"}, stdin: sales}},
+		{name: "table_skip", in: in{args: []string{"table", "change", "q1", "q2", "-on-error", "skip"}, stdin: sales}},
+		{name: "table_fail", in: in{args: []string{"table", "change", "q1", "q2"}, stdin: sales}},
+		{
+			name: "table_tsv",
+			in: in{
+				args:  []string{"table", "change", "1", "2", "-header=false", "-delimiter", "tab", "-precision=1"},
+				stdin: "10\t15\n20\t\"5\"\n0\t1\n",
+			},
+		},
+		{name: "table_unknown_column", in: in{args: []string{"table", "share", "q3"}, stdin: sales}},
+		{name: "table_unknown_operation", in: in{args: []string{"table", "sum", "q1"}, stdin: sales}},
+		{name: "table_invalid_number", in: in{args: []string{"table", "rowchange", "q1"}, stdin: "q1\n1\ntwo\n"}},
+		{name: "repl_help", in: in{args: []string{"repl", "-h"}}},
+		{
+			name: "repl_session",
+			in: in{
+				args: []string{"repl", "-precision", "2"},
+				stdin: "# a comment\n15% of 240\nwhat percent is $1 of 120\ntotal = 240\nset = 2\nvars\n" +
+					"explain 200 minus 20% minus 10%\nexplain (change from -80 to 100) / (5 - 5)\n" +
+					"set rounding floor\nset precision x\nset policy lenient\nset\n1 / 3\n" +
+					"explain 15% öf 240\n10% of unknown\nexplain\nexit\n1 + 1\n",
+			},
+		},
+		{name: "repl_arguments", in: in{args: []string{"repl", "15%", "of", "240"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			r := strings.NewReader(tt.in.stdin)
+			if _, err := r.Seek(tt.in.skip, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdin io.Reader = r
+			if tt.in.pipe {
+				stdin = io.MultiReader(stdin)
+			}
+
+			path := filepath.Join("testdata", tt.name+".golden")
+
+			// Act
+			code := run(tt.in.args, stdin, &stdout, &stderr)
+
+			// Assert
+			got := fmt.Sprintf("-- stdout --\n%s-- stderr --\n%s-- exit --\n%d\n", &stdout, &stderr, code)
+			if os.Getenv("UPDATE_GOLDEN") == "1" {
+				if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden file: %v", err)
+			}
+			if diff := cmp.Diff(string(want), got); diff != "" {
+				t.Errorf("run(%q) mismatch (-want +got):\n%s", tt.in.args, diff)
+			}
+		})
+	}
+}
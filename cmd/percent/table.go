@@ -0,0 +1,506 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// Policies of the -on-error flag of the table command for a row whose value cannot be computed.
+const (
+	onErrorFail  = "fail"
+	onErrorSkip  = "skip"
+	onErrorEmpty = "empty"
+)
+
+// errInput reports a missing or invalid value in the input of the table command.
+var errInput = errors.New("invalid input")
+
+// tableOp is an operation of the table command that computes a column from the columns of
+// each row.
+type tableOp struct {
+	// name is the name of the operation, e.g. "share".
+	name string
+	// args names the columns the operation reads.
+	args []string
+	// summary describes the computed column.
+	summary string
+}
+
+// tableOps returns the operations of the table command.
+func tableOps() []tableOp {
+	return []tableOp{
+		{name: "share", args: []string{"column"}, summary: "the percentage of the column total in each row"},
+		{name: "change", args: []string{"old", "new"}, summary: "the percentage change from column old to new"},
+		{name: "rowchange", args: []string{"column"}, summary: "the percentage change from the previous row"},
+	}
+}
+
+// table holds the flags of the table command.
+type table struct {
+	cfg       *config
+	delimiter string
+	header    bool
+	name      string
+	onError   string
+	total     string
+}
+
+// newTable returns a table whose fields are set by the flags it defines in fs.
+func newTable(fs *flag.FlagSet) *table {
+	t := &table{cfg: newConfig(fs)}
+
+	fs.StringVar(&t.delimiter, "delimiter", ",", "field `delimiter`, e.g. \"tab\" for TSV")
+	fs.BoolVar(&t.header, "header", true, "read and write a header row; columns are selected by index if false")
+	fs.StringVar(&t.name, "name", "", "`name` of the computed column (default the operation)")
+	fs.StringVar(&t.onError, "on-error", onErrorFail,
+		"`policy` for a row whose value is missing or cannot be computed: "+
+			onErrorFail+", "+onErrorSkip+" or "+onErrorEmpty)
+	fs.StringVar(&t.total, "total", "", "`total` of share instead of the column sum, which reads the input twice")
+
+	return t
+}
+
+// runTable runs the table command with args, reading CSV or TSV from stdin and writing it
+// with the computed column appended to stdout, and returns the exit status.
+func runTable(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("table", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	t := newTable(fs)
+
+	pos, err := parseArgs(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		tableUsage(stdout, fs)
+
+		return exitOK
+	}
+
+	var op tableOp
+	if err == nil {
+		op, err = lookupTableOp(pos)
+	}
+
+	var opts []percent.Option
+	if err == nil {
+		opts, err = t.cfg.options()
+	}
+
+	var comma rune
+	if err == nil {
+		comma, err = t.comma()
+	}
+
+	var total float64
+	if err == nil {
+		total, err = t.totalFlag(op)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "percent table: %v\n%s\n", err, tableSynopsis())
+		fmt.Fprintln(stderr, `Run "percent table -h" for details.`)
+
+		return exitUsage
+	}
+
+	in := stdin
+	if op.name == "share" && t.total == "" {
+		src, start, cleanup, err := rewindable(stdin)
+		if err == nil {
+			defer cleanup()
+
+			total, err = t.sum(src, comma, pos[1])
+		}
+
+		if err == nil {
+			_, err = src.Seek(start, io.SeekStart)
+		}
+
+		if err != nil {
+			fmt.Fprintf(stderr, "percent table: %v\n", err)
+
+			return exitCode(err)
+		}
+
+		in = src
+	}
+
+	err = t.run(in, stdout, stderr, comma, op, pos[1:], t.calc(op, total, opts))
+	if err != nil {
+		fmt.Fprintf(stderr, "percent table: %v\n", err)
+	}
+
+	return exitCode(err)
+}
+
+// lookupTableOp returns the operation named by the first positional argument and checks the
+// number of columns that follow it.
+func lookupTableOp(pos []string) (tableOp, error) {
+	if len(pos) == 0 {
+		return tableOp{}, fmt.Errorf("%w: missing operation", errUsage)
+	}
+
+	for _, op := range tableOps() {
+		if op.name != pos[0] {
+			continue
+		}
+
+		if len(pos)-1 != len(op.args) {
+			return tableOp{}, fmt.Errorf("%w: %s takes %d columns, got %d", errUsage, op.name, len(op.args), len(pos)-1)
+		}
+
+		return op, nil
+	}
+
+	return tableOp{}, fmt.Errorf("%w: unknown operation %q", errUsage, pos[0])
+}
+
+// tableSynopsis returns the usage line of the table command.
+func tableSynopsis() string {
+	ops := make([]string, 0, len(tableOps()))
+	for _, op := range tableOps() {
+		ops = append(ops, op.name+" "+strings.Join(op.args, " "))
+	}
+
+	return "usage: percent table [flags] " + strings.Join(ops, " | ")
+}
+
+// tableUsage writes the usage of the table command, including its operations and flags, to w.
+func tableUsage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "%s\n\n", tableSynopsis())
+	fmt.Fprintln(w, "Reads CSV or TSV from standard input and writes it to standard output with a column")
+	fmt.Fprintln(w, "appended. A column is selected by its name in the header or by its index, starting at 1.")
+	fmt.Fprintln(w, "\noperations:")
+
+	for _, op := range tableOps() {
+		fmt.Fprintf(w, "  %-9s %-8s %s\n", op.name, strings.Join(op.args, " "), op.summary)
+	}
+
+	fmt.Fprintln(w, "\nflags:")
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+	fs.SetOutput(io.Discard)
+}
+
+// comma checks the error policy of t and returns the field delimiter selected by t.
+func (t *table) comma() (rune, error) {
+	switch t.onError {
+	case onErrorFail, onErrorSkip, onErrorEmpty:
+	default:
+		return 0, fmt.Errorf("%w: unknown error policy %q", errUsage, t.onError)
+	}
+
+	switch t.delimiter {
+	case "tab", `\t`:
+		return '\t', nil
+	}
+
+	r, n := utf8.DecodeRuneInString(t.delimiter)
+	if n == 0 || n != len(t.delimiter) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("%w: invalid delimiter %q", errUsage, t.delimiter)
+	}
+
+	return r, nil
+}
+
+// totalFlag returns the total given by the -total flag, which only applies to share.
+func (t *table) totalFlag(op tableOp) (float64, error) {
+	if t.total == "" {
+		return 0, nil
+	}
+
+	if op.name != "share" {
+		return 0, fmt.Errorf("%w: -total only applies to share", errUsage)
+	}
+
+	total, err := parseValue(t.total)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid total %q", errUsage, t.total)
+	}
+
+	return total, nil
+}
+
+// calc returns the function that computes the value of op for the numbers x in the selected
+// columns of a row. The function of rowchange holds the last value it was given.
+func (t *table) calc(op tableOp, total float64, opts []percent.Option) func(x []float64) (string, error) {
+	format := func(v float64, err error) (string, error) {
+		if err != nil {
+			return "", err
+		}
+
+		return t.cfg.number(v), nil
+	}
+
+	switch op.name {
+	case "share":
+		return func(x []float64) (string, error) {
+			return format(percent.Of(x[0], total, opts...))
+		}
+	case "change":
+		return func(x []float64) (string, error) {
+			return format(percent.Change(x[0], x[1], opts...))
+		}
+	}
+
+	var prev float64
+
+	first := true
+
+	return func(x []float64) (string, error) {
+		if first {
+			prev, first = x[0], false
+
+			return "", nil
+		}
+
+		v, err := percent.Change(prev, x[0], opts...)
+		prev = x[0]
+
+		return format(v, err)
+	}
+}
+
+// reader returns a CSV reader of r that reuses its records, so that memory stays constant
+// whatever the size of the input.
+func (t *table) reader(r io.Reader, comma rune) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	return cr
+}
+
+// start reads the header from cr, if t has one, and returns it along with the indexes of
+// the columns named by cols.
+func (t *table) start(cr *csv.Reader, cols []string) ([]string, []int, error) {
+	var header []string
+
+	if t.header {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: missing header", errInput)
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", errInput, err)
+		}
+
+		header = slices.Clone(record)
+	}
+
+	index := make([]int, len(cols))
+	for i, c := range cols {
+		j, err := column(header, c)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		index[i] = j
+	}
+
+	return header, index, nil
+}
+
+// column returns the index of the column c, given by its name in header or its index
+// starting at 1.
+func column(header []string, c string) (int, error) {
+	if i := slices.Index(header, c); i >= 0 {
+		return i, nil
+	}
+
+	i, err := strconv.Atoi(c)
+	if err != nil || i < 1 {
+		return 0, fmt.Errorf("%w: unknown column %q", errUsage, c)
+	}
+
+	return i - 1, nil
+}
+
+// values parses the fields of record at index into x.
+func values(record []string, index []int, x []float64) error {
+	for i, j := range index {
+		if j >= len(record) || strings.TrimSpace(record[j]) == "" {
+			return fmt.Errorf("%w: missing value in column %d", errInput, j+1)
+		}
+
+		v, err := parseValue(record[j])
+		if err != nil {
+			return fmt.Errorf("%w: invalid number %q in column %d", errInput, record[j], j+1)
+		}
+
+		x[i] = v
+	}
+
+	return nil
+}
+
+// parseValue parses the field s as a plain number, rejecting NaN and infinities.
+func parseValue(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err == nil && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		return 0, percent.ErrNotFinite
+	}
+
+	return v, err
+}
+
+// sum returns the sum of the valid numbers in column col of the CSV in r, compensated for
+// rounding errors by percent.Summarize.
+func (t *table) sum(r io.Reader, comma rune, col string) (float64, error) {
+	cr := t.reader(r, comma)
+
+	_, index, err := t.start(cr, []string{col})
+	if err != nil {
+		return 0, err
+	}
+
+	var column []float64
+
+	x := make([]float64, 1)
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", errInput, err)
+		}
+
+		if values(record, index, x) == nil {
+			column = append(column, x[0])
+		}
+	}
+
+	s, err := percent.Summarize(context.Background(), column)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Sum, nil
+}
+
+// run copies the CSV in r to w, appending the value computed by calc from the columns cols
+// of each row. A row whose value cannot be computed is handled by the -on-error policy and
+// reported to stderr unless the policy is to fail.
+func (t *table) run(
+	r io.Reader, w, stderr io.Writer, comma rune, op tableOp, cols []string,
+	calc func(x []float64) (string, error),
+) error {
+	cr := t.reader(r, comma)
+
+	header, index, err := t.start(cr, cols)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	name := t.name
+	if name == "" {
+		name = op.name
+	}
+
+	if t.header {
+		_ = cw.Write(append(header, name))
+	}
+
+	x := make([]float64, len(index))
+	var out []string
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			cw.Flush()
+
+			return fmt.Errorf("%w: %w", errInput, err)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		err = values(record, index, x)
+
+		var v string
+		if err == nil {
+			v, err = calc(x)
+		}
+
+		if err != nil {
+			if t.onError == onErrorFail {
+				cw.Flush()
+
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+
+			fmt.Fprintf(stderr, "percent table: line %d: %v (%s)\n", line, err, t.onError)
+
+			if t.onError == onErrorSkip {
+				continue
+			}
+		}
+
+		out = append(append(out[:0], record...), v)
+		if err := cw.Write(out); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// rewindable returns r as an io.ReadSeeker and the offset at which r is positioned, so that
+// it can be read twice from there. If r cannot seek, such as a pipe, it is first copied to a
+// temporary file, which the returned function removes.
+func rewindable(r io.Reader) (io.ReadSeeker, int64, func(), error) {
+	if s, ok := r.(io.ReadSeeker); ok {
+		if start, err := s.Seek(0, io.SeekCurrent); err == nil {
+			return s, start, func() {}, nil
+		}
+	}
+
+	f, err := os.CreateTemp("", "percent-table-*")
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	cleanup := func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+
+	_, err = io.Copy(f, r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		cleanup()
+
+		return nil, 0, nil, err
+	}
+
+	return f, 0, cleanup, nil
+}
@@ -0,0 +1,398 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// errRequest reports a request body that does not match the parameters of its endpoint.
+var errRequest = errors.New("invalid request")
+
+// kind is the JSON type of a parameter or result.
+type kind int
+
+const (
+	kindNumber kind = iota
+	kindInteger
+	kindString
+	kindNumbers
+	kindIntegers
+	kindPrecision
+)
+
+// Bounds of a precision field. A precision of -1 leaves results unrounded, and 15 decimal
+// places are as many as a float64 holds for a percentage. Larger values are rejected, as
+// rounding to them costs time and memory without changing the result.
+const (
+	precisionMin = -1
+	precisionMax = 15
+)
+
+// param is a field of the request body of an endpoint.
+type param struct {
+	// name is the name of the field.
+	name string
+	// kind is the JSON type of the field.
+	kind kind
+	// summary describes the field.
+	summary string
+	// enum lists the values of a string field, if it is restricted.
+	enum []string
+}
+
+// endpoint is an operation of package percent served at /v1/{name}.
+type endpoint struct {
+	// name is the name of the endpoint, e.g. "of".
+	name string
+	// summary describes the result of the endpoint.
+	summary string
+	// params are the required fields of the request body.
+	params []param
+	// options reports whether the request body may also hold the fields of optionParams.
+	options bool
+	// result is the JSON type of the result.
+	result kind
+	// call computes the result from the fields of a request body.
+	call func(a *args, opts []percent.Option) (any, error)
+}
+
+// endpoints returns the endpoints of the server, one for each operation of package percent.
+func endpoints() []endpoint {
+	return slices.Concat(floatEndpoints(), intEndpoints(), []endpoint{
+		{
+			name: "distribute", summary: "the shares of parts, rounded to decimals places so that they add up to 100",
+			params: []param{
+				{name: "parts", kind: kindNumbers, summary: "the parts to distribute", enum: nil},
+				{name: "decimals", kind: kindInteger, summary: "the number of decimal places", enum: nil},
+				{name: "method", kind: kindString, summary: "the reconciliation method", enum: distributionMethods()},
+			},
+			options: false, result: kindNumbers,
+			call: func(a *args, _ []percent.Option) (any, error) {
+				return percent.Distribute(a.numbers("parts"), int(a.integer("decimals")),
+					percent.DistributionMethod(a.choice("method", distributionMethods())))
+			},
+		},
+		{
+			name: "allocate", summary: "amount in minor units split by percents, summing to exactly amount",
+			params: []param{
+				{name: "amount", kind: kindInteger, summary: "the amount in minor units, such as cents", enum: nil},
+				{name: "percents", kind: kindNumbers, summary: "the percentages of the splits, summing to 100", enum: nil},
+				{name: "remainder", kind: kindString, summary: "the splits that receive leftover units", enum: remainderPolicies()},
+			},
+			options: false, result: kindIntegers,
+			call: func(a *args, _ []percent.Option) (any, error) {
+				p := a.numbers("percents")
+
+				percents := make([]percent.Percentage, len(p))
+				for i, v := range p {
+					percents[i] = percent.Percentage(v)
+				}
+
+				return percent.AllocateWith(a.integer("amount"), percents,
+					percent.RemainderPolicy(a.choice("remainder", remainderPolicies())))
+			},
+		},
+		{
+			name: "parse", summary: "the percentage in text, such as \"12.5%\", \"125‰\" or \"1250bp\"",
+			params:  []param{{name: "text", kind: kindString, summary: "the text to parse", enum: nil}},
+			options: false, result: kindNumber,
+			call: func(a *args, _ []percent.Option) (any, error) {
+				p, err := percent.Parse(a.text("text"))
+
+				return float64(p), err
+			},
+		},
+		{
+			name: "parse-ratio", summary: "the ratio in text, such as \"0.125\" or \"12.5%\"",
+			params:  []param{{name: "text", kind: kindString, summary: "the text to parse", enum: nil}},
+			options: false, result: kindNumber,
+			call: func(a *args, _ []percent.Option) (any, error) {
+				r, err := percent.ParseRatio(a.text("text"))
+
+				return float64(r), err
+			},
+		},
+		{
+			name: "round", summary: "x rounded to precision decimal places",
+			params: []param{
+				{name: "x", kind: kindNumber, summary: "the number to round", enum: nil},
+				{name: "precision", kind: kindPrecision, summary: "the number of decimal places", enum: nil},
+				{name: "rounding", kind: kindString, summary: "the rounding mode", enum: names(percent.RoundingModes())},
+			},
+			options: false, result: kindNumber,
+			call: func(a *args, _ []percent.Option) (any, error) {
+				return percent.Round(a.number("x"), a.precision("precision"),
+					lookup(a, "rounding", percent.LookupRoundingMode)), nil
+			},
+		},
+	})
+}
+
+// floatEndpoints returns the endpoints of the operations on two or fewer numbers.
+func floatEndpoints() []endpoint {
+	type op = func(x []float64, opts []percent.Option) (float64, error)
+
+	float := func(name, summary string, fields []string, f op) endpoint {
+		params := make([]param, len(fields))
+		for i, field := range fields {
+			params[i] = param{name: field, kind: kindNumber, summary: "", enum: nil}
+		}
+
+		return endpoint{
+			name: name, summary: summary, params: params, options: true, result: kindNumber,
+			call: func(a *args, opts []percent.Option) (any, error) {
+				x := make([]float64, len(fields))
+				for i, field := range fields {
+					x[i] = a.number(field)
+				}
+
+				return f(x, opts)
+			},
+		}
+	}
+
+	return []endpoint{
+		float("percent", "percent of value", []string{"percent", "value"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.Percent(x[0], x[1], opts...) }),
+		float("of", "the percentage part is of total", []string{"part", "total"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.Of(x[0], x[1], opts...) }),
+		float("change", "the percentage change from old to new", []string{"old", "new"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.Change(x[0], x[1], opts...) }),
+		float("remain", "the value remaining after a decrease by percent", []string{"percent", "value"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.Remain(x[0], x[1], opts...) }),
+		float("from-ratio", "ratio as a percentage", []string{"ratio"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.FromRatio(x[0], opts...) }),
+		float("to-ratio", "percent as a ratio", []string{"percent"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.ToRatio(x[0], opts...) }),
+		float("whole", "the value of which part is percent percent", []string{"percent", "part"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.Whole(x[0], x[1], opts...) }),
+		float("before-increase", "the value that became value after an increase by percent", []string{"percent", "value"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.BeforeIncrease(x[0], x[1], opts...)
+			}),
+		float("before-decrease", "the value that became value after a decrease by percent", []string{"percent", "value"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.BeforeDecrease(x[0], x[1], opts...)
+			}),
+		float("percent-more", "how many percent x is more than y", []string{"x", "y"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.PercentMore(x[0], x[1], opts...)
+			}),
+		float("restore", "the increase in percent that restores a value after a decrease by percent", []string{"percent"},
+			func(x []float64, opts []percent.Option) (float64, error) { return percent.Restore(x[0], opts...) }),
+		float("apply", "percent of value, where percent may be negative or exceed 100", []string{"percent", "value"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.Apply(percent.Percentage(x[0]), x[1], opts...)
+			}),
+		float("log-change", "the logarithmic change from old to new", []string{"old", "new"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.LogChange(x[0], x[1], opts...)
+			}),
+		float("symmetric-change", "the symmetric percentage change from old to new", []string{"old", "new"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.SymmetricChange(x[0], x[1], opts...)
+			}),
+		float("point-change", "the change from old to new in percentage points", []string{"old", "new"},
+			func(x []float64, opts []percent.Option) (float64, error) {
+				return percent.PointChange(x[0], x[1], opts...)
+			}),
+	}
+}
+
+// intEndpoints returns the endpoints of the integer operations, whose arguments and results
+// are whole numbers.
+func intEndpoints() []endpoint {
+	type op = func(x, y int64, opts []percent.Option) (int64, error)
+
+	integer := func(name, summary string, fields [2]string, f op) endpoint {
+		return endpoint{
+			name: name, summary: summary,
+			params: []param{
+				{name: fields[0], kind: kindInteger, summary: "", enum: nil},
+				{name: fields[1], kind: kindInteger, summary: "", enum: nil},
+			},
+			options: true, result: kindInteger,
+			call: func(a *args, opts []percent.Option) (any, error) {
+				return f(a.integer(fields[0]), a.integer(fields[1]), opts)
+			},
+		}
+	}
+
+	return []endpoint{
+		integer("percent-int", "percent of value as a whole number", [2]string{"percent", "value"},
+			func(x, y int64, opts []percent.Option) (int64, error) { return percent.PercentInt(x, y, opts...) }),
+		integer("of-int", "the whole percentage part is of total", [2]string{"part", "total"},
+			func(x, y int64, opts []percent.Option) (int64, error) { return percent.OfInt(x, y, opts...) }),
+		integer("change-int", "the whole percentage change from old to new", [2]string{"old", "new"},
+			func(x, y int64, opts []percent.Option) (int64, error) { return percent.ChangeInt(x, y, opts...) }),
+		integer("remain-int", "the whole value remaining after a decrease by percent", [2]string{"percent", "value"},
+			func(x, y int64, opts []percent.Option) (int64, error) { return percent.RemainInt(x, y, opts...) }),
+	}
+}
+
+// optionParams returns the optional fields of the endpoints that take options.
+func optionParams() []param {
+	return []param{
+		{name: "precision", kind: kindPrecision, summary: "round the result to n decimal places", enum: nil},
+		{name: "rounding", kind: kindString, summary: "the rounding mode", enum: names(percent.RoundingModes())},
+		{name: "policy", kind: kindString, summary: "the range policy", enum: names(percent.Policies())},
+	}
+}
+
+// distributionMethods returns the names of the distribution methods of Distribute, in the
+// order of their values.
+func distributionMethods() []string {
+	return []string{"largest-remainder", "cascade"}
+}
+
+// remainderPolicies returns the names of the remainder policies of AllocateWith, in the order
+// of their values.
+func remainderPolicies() []string {
+	return []string{"largest", "first", "last", "round-robin"}
+}
+
+// names returns the names of values.
+func names[T fmt.Stringer](values []T) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = v.String()
+	}
+
+	return s
+}
+
+// args holds the fields of a request body and the first error met while reading them, so
+// that an endpoint can read its fields without checking each one.
+type args struct {
+	fields map[string]json.RawMessage
+	err    error
+}
+
+// newArgs returns the fields of a request body for e, or an error wrapping errRequest if one
+// of them is not a parameter of e.
+func newArgs(e endpoint, fields map[string]json.RawMessage) (*args, error) {
+	for name := range fields {
+		if !slices.ContainsFunc(e.params, func(p param) bool { return p.name == name }) &&
+			(!e.options || !slices.ContainsFunc(optionParams(), func(p param) bool { return p.name == name })) {
+			return nil, fmt.Errorf("%w: unknown field %q", errRequest, name)
+		}
+	}
+
+	return &args{fields: fields, err: nil}, nil
+}
+
+// has reports whether the field name is present and not null.
+func (a *args) has(name string) bool {
+	raw, ok := a.fields[name]
+
+	return ok && string(raw) != "null"
+}
+
+// get decodes the field name into v.
+func (a *args) get(name string, v any) {
+	if a.err != nil {
+		return
+	}
+
+	if !a.has(name) {
+		a.err = fmt.Errorf("%w: missing field %q", errRequest, name)
+
+		return
+	}
+
+	if err := json.Unmarshal(a.fields[name], v); err != nil {
+		a.err = fmt.Errorf("%w: field %q: %w", errRequest, name, err)
+	}
+}
+
+// number returns the field name as a number.
+func (a *args) number(name string) float64 {
+	var v float64
+
+	a.get(name, &v)
+
+	return v
+}
+
+// integer returns the field name as a whole number.
+func (a *args) integer(name string) int64 {
+	var v int64
+
+	a.get(name, &v)
+
+	return v
+}
+
+// precision returns the field name as a number of decimal places within [precisionMin,
+// precisionMax].
+func (a *args) precision(name string) int {
+	n := a.integer(name)
+	if (n < precisionMin || n > precisionMax) && a.err == nil {
+		a.err = fmt.Errorf("%w: field %q: %d not in [%d, %d]", errRequest, name, n, precisionMin, precisionMax)
+	}
+
+	return int(n)
+}
+
+// text returns the field name as a string.
+func (a *args) text(name string) string {
+	var v string
+
+	a.get(name, &v)
+
+	return v
+}
+
+// numbers returns the field name as an array of numbers.
+func (a *args) numbers(name string) []float64 {
+	var v []float64
+
+	a.get(name, &v)
+
+	return v
+}
+
+// choice returns the index of the field name in values.
+func (a *args) choice(name string, values []string) int {
+	i := slices.Index(values, a.text(name))
+	if i < 0 && a.err == nil {
+		a.err = fmt.Errorf("%w: field %q: unknown value %q", errRequest, name, a.text(name))
+	}
+
+	return max(i, 0)
+}
+
+// lookup returns the value named by the field name of a, as found by find.
+func lookup[T any](a *args, name string, find func(string) (T, bool)) T {
+	v, ok := find(a.text(name))
+	if !ok && a.err == nil {
+		a.err = fmt.Errorf("%w: field %q: unknown value %q", errRequest, name, a.text(name))
+	}
+
+	return v
+}
+
+// options returns the options given by the optional fields of optionParams.
+func (a *args) options() []percent.Option {
+	var opts []percent.Option
+
+	if a.has("precision") {
+		opts = append(opts, percent.WithPrecision(a.precision("precision")))
+	}
+
+	if a.has("rounding") {
+		opts = append(opts, percent.WithRounding(lookup(a, "rounding", percent.LookupRoundingMode)))
+	}
+
+	if a.has("policy") {
+		opts = append(opts, percent.WithPolicy(lookup(a, "policy", percent.LookupPolicy)))
+	}
+
+	return opts
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Percentd is an HTTP server that exposes the operations of package percent as JSON
+// endpoints, so that services in other languages share its semantics.
+//
+// Usage:
+//
+//	percentd [flags]
+//
+// The flags are:
+//
+//	-addr address    listen on address (default "localhost:8080")
+//	-max-body n      reject request bodies larger than n bytes (default 1048576)
+//	-max-batch n     reject batches of more than n requests (default 1000)
+//
+// Each operation is served at POST /v1/{name}, e.g. /v1/of, with a JSON object of its
+// arguments and the optional fields precision, rounding and policy:
+//
+//	{"part": 1, "total": 8, "precision": 2}
+//
+// It responds with {"result": 12.5}. POST /v1/{name}/batch takes an array of such objects and
+// responds with {"results": [...]}, holding a result or an error for each of them in order.
+//
+// A failed request responds with {"error": {"code": ..., "message": ...}}, where the code
+// names the error of package percent, e.g. "divide_by_zero" with status 422, or a failure of
+// the request itself, e.g. "invalid_request" with status 400 or "too_large" with status 413.
+//
+// GET /openapi.json serves the OpenAPI document of the endpoints.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run runs the server with the command line args until it is interrupted, writes errors to
+// stderr, and returns the exit status.
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("percentd", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	addr := fs.String("addr", "localhost:8080", "listen on `address`")
+	body := fs.Int64("max-body", 1<<20, "reject request bodies larger than `n` bytes")
+	batch := fs.Int("max-batch", 1000, "reject batches of more than `n` requests")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+
+		return 2
+	}
+
+	handler, err := newServer(limits{body: *body, batch: *batch})
+	if err != nil {
+		fmt.Fprintf(stderr, "percentd: %v\n", err)
+
+		return 1
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       time.Minute,
+		MaxHeaderBytes:    16 << 10,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err = srv.Shutdown(shutdown)
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "percentd: %v\n", err)
+
+		return 1
+	}
+
+	return 0
+}
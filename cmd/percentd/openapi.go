@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// object is a JSON object of the OpenAPI document.
+type object = map[string]any
+
+// openAPI returns the OpenAPI 3.1 document of the endpoints, their batch variants and the
+// document itself.
+func openAPI(eps []endpoint) object {
+	paths := object{
+		"/openapi.json": object{"get": object{
+			"operationId": "openAPI",
+			"summary":     "the OpenAPI document of the service",
+			"responses": object{
+				"200": object{"description": "the document", "content": content(object{"type": "object"})},
+			},
+		}},
+	}
+
+	schemas := object{
+		"Error": object{
+			"type":     "object",
+			"required": []string{"code", "message"},
+			"properties": object{
+				"code": object{
+					"type": "string", "enum": codes(),
+					"description": "the sentinel error of package percent, or a failure of the request",
+				},
+				"message": object{"type": "string"},
+				"op":      object{"type": "string", "description": "the operation that failed, e.g. \"Of\""},
+				"inputs": object{
+					"type": "array", "items": object{"type": []string{"number", "null"}},
+					"description": "the inputs of the operation, null if not finite",
+				},
+				"min":    object{"type": "number", "description": "the lower bound of the offending input"},
+				"max":    object{"type": "number", "description": "the upper bound of the offending input"},
+				"offset": object{"type": "integer", "description": "the byte offset of a syntax error"},
+			},
+		},
+		"ErrorResponse": object{
+			"type":       "object",
+			"required":   []string{"error"},
+			"properties": object{"error": ref("Error")},
+		},
+	}
+
+	for _, e := range eps {
+		name := schemaName(e.name)
+		schemas[name+"Request"] = requestSchema(e)
+		schemas[name+"Response"] = object{
+			"type": "object",
+			"properties": object{
+				"result": schema(e.result, nil),
+				"error":  ref("Error"),
+			},
+		}
+
+		paths["/v1/"+e.name] = object{"post": operation(
+			operationID(e.name), e.summary, ref(name+"Request"),
+			object{"type": "object", "required": []string{"result"}, "properties": object{"result": schema(e.result, nil)}},
+		)}
+		paths["/v1/"+e.name+"/batch"] = object{"post": operation(
+			operationID(e.name)+"Batch", e.summary+", for each request of a batch",
+			object{"type": "array", "items": ref(name + "Request")},
+			object{
+				"type":       "object",
+				"required":   []string{"results"},
+				"properties": object{"results": object{"type": "array", "items": ref(name + "Response")}},
+			},
+		)}
+	}
+
+	return object{
+		"openapi": "3.1.0",
+		"info": object{
+			"title":       "percent",
+			"version":     "1",
+			"description": "The operations of package github.com/sentenz/percent/pkg/percent.",
+		},
+		"paths":      paths,
+		"components": object{"schemas": schemas},
+	}
+}
+
+// operation returns a POST operation with the request and response schemas, and the errors
+// of the server.
+func operation(id, summary string, request, response object) object {
+	responses := object{
+		"200": object{"description": "the result", "content": content(response)},
+	}
+
+	for _, c := range errorCodes() {
+		status := strconv.Itoa(c.status)
+		if _, ok := responses[status]; !ok {
+			responses[status] = object{
+				"description": strings.ToLower(http.StatusText(c.status)),
+				"content":     content(ref("ErrorResponse")),
+			}
+		}
+	}
+
+	return object{
+		"operationId": id,
+		"summary":     summary,
+		"requestBody": object{"required": true, "content": content(request)},
+		"responses":   responses,
+	}
+}
+
+// requestSchema returns the schema of the request body of e.
+func requestSchema(e endpoint) object {
+	props := object{}
+	required := make([]string, 0, len(e.params))
+
+	for _, p := range e.params {
+		props[p.name] = paramSchema(p)
+		required = append(required, p.name)
+	}
+
+	if e.options {
+		for _, p := range optionParams() {
+			props[p.name] = paramSchema(p)
+		}
+	}
+
+	return object{
+		"type":                 "object",
+		"required":             required,
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+// paramSchema returns the schema of p.
+func paramSchema(p param) object {
+	s := schema(p.kind, p.enum)
+	if p.summary != "" {
+		s["description"] = p.summary
+	}
+
+	return s
+}
+
+// schema returns the schema of a value of kind k, restricted to enum if it is not empty.
+func schema(k kind, enum []string) object {
+	switch k {
+	case kindNumber:
+		return object{"type": "number"}
+	case kindInteger:
+		return object{"type": "integer"}
+	case kindString:
+		if len(enum) > 0 {
+			return object{"type": "string", "enum": enum}
+		}
+
+		return object{"type": "string"}
+	case kindNumbers:
+		return object{"type": "array", "items": object{"type": "number"}}
+	case kindIntegers:
+		return object{"type": "array", "items": object{"type": "integer"}}
+	case kindPrecision:
+		return object{"type": "integer", "minimum": precisionMin, "maximum": precisionMax}
+	}
+
+	return object{}
+}
+
+// content returns the JSON content of a request or response with schema s.
+func content(s object) object {
+	return object{"application/json": object{"schema": s}}
+}
+
+// ref returns a reference to the component schema name.
+func ref(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+// codes returns the codes of the errors of the server.
+func codes() []string {
+	s := make([]string, 0, len(errorCodes())+3)
+	for _, c := range errorCodes() {
+		s = append(s, c.code)
+	}
+
+	return append(s, "not_found", "method_not_allowed", "internal")
+}
+
+// schemaName returns the name of an endpoint in the names of its schemas, e.g. "PercentMore"
+// for "percent-more".
+func schemaName(name string) string {
+	var b strings.Builder
+
+	for word := range strings.SplitSeq(name, "-") {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	return b.String()
+}
+
+// operationID returns the operation ID of an endpoint, e.g. "percentMore" for "percent-more".
+func operationID(name string) string {
+	s := schemaName(name)
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// errTooLarge reports a request body or batch that exceeds the limits of the server.
+var errTooLarge = errors.New("request too large")
+
+// limits bounds the requests accepted by the server.
+type limits struct {
+	// body is the maximum size of a request body in bytes.
+	body int64
+	// batch is the maximum number of requests in a batch.
+	batch int
+}
+
+// server serves the endpoints over HTTP.
+type server struct {
+	limits  limits
+	openAPI []byte
+	paths   []string
+}
+
+// newServer returns the handler of the endpoints, their batch variants and the OpenAPI
+// document, which accepts requests within l.
+func newServer(l limits) (http.Handler, error) {
+	doc, err := json.MarshalIndent(openAPI(endpoints()), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &server{limits: l, openAPI: append(doc, '\n'), paths: nil}
+	mux := http.NewServeMux()
+
+	for _, e := range endpoints() {
+		mux.HandleFunc("POST /v1/"+e.name, s.single(e))
+		mux.HandleFunc("POST /v1/"+e.name+"/batch", s.batch(e))
+		s.paths = append(s.paths, "/v1/"+e.name, "/v1/"+e.name+"/batch")
+	}
+
+	mux.HandleFunc("GET /openapi.json", s.document)
+	mux.HandleFunc("/", s.notFound)
+
+	return mux, nil
+}
+
+// response is the body of a successful response, or of an item of a batch response.
+type response struct {
+	Result any           `json:"result,omitempty"`
+	Error  *errorDetails `json:"error,omitempty"`
+}
+
+// batchResponse is the body of a batch response, with a response for each request in order.
+type batchResponse struct {
+	Results []response `json:"results"`
+}
+
+// errorDetails describes a failed request. Code names the sentinel error of package percent,
+// e.g. "divide_by_zero", or a failure of the request itself, e.g. "invalid_request". Op,
+// Inputs, Min and Max are those of a *percent.Error, and Offset is that of a
+// *percent.SyntaxError.
+type errorDetails struct {
+	Code    string     `json:"code"`
+	Message string     `json:"message"`
+	Op      string     `json:"op,omitempty"`
+	Inputs  []*float64 `json:"inputs,omitempty"`
+	Min     *float64   `json:"min,omitempty"`
+	Max     *float64   `json:"max,omitempty"`
+	Offset  *int       `json:"offset,omitempty"`
+}
+
+// errorCodes returns the codes of the errors of a request and the status of a response
+// with such an error.
+func errorCodes() []struct {
+	err    error
+	code   string
+	status int
+} {
+	return []struct {
+		err    error
+		code   string
+		status int
+	}{
+		{errRequest, "invalid_request", http.StatusBadRequest},
+		{errTooLarge, "too_large", http.StatusRequestEntityTooLarge},
+		{percent.ErrOutOfRange, "out_of_range", http.StatusUnprocessableEntity},
+		{percent.ErrDivideByZero, "divide_by_zero", http.StatusUnprocessableEntity},
+		{percent.ErrPartGreaterThanTotal, "part_greater_than_total", http.StatusUnprocessableEntity},
+		{percent.ErrSyntax, "syntax", http.StatusUnprocessableEntity},
+		{percent.ErrSumMismatch, "sum_mismatch", http.StatusUnprocessableEntity},
+		{percent.ErrNotFinite, "not_finite", http.StatusUnprocessableEntity},
+		{percent.ErrOverflow, "overflow", http.StatusUnprocessableEntity},
+	}
+}
+
+// details returns the details of err and the status of a response with it.
+func details(err error) (*errorDetails, int) {
+	d := &errorDetails{
+		Code: "internal", Message: err.Error(), Op: "", Inputs: nil, Min: nil, Max: nil, Offset: nil,
+	}
+	status := http.StatusInternalServerError
+
+	for _, c := range errorCodes() {
+		if errors.Is(err, c.err) {
+			d.Code, status = c.code, c.status
+
+			break
+		}
+	}
+
+	if pe := (*percent.Error)(nil); errors.As(err, &pe) {
+		d.Op = pe.Op
+		for _, x := range pe.Inputs {
+			d.Inputs = append(d.Inputs, number(x))
+		}
+
+		if pe.Min != 0 || pe.Max != 0 {
+			d.Min, d.Max = number(pe.Min), number(pe.Max)
+		}
+	}
+
+	if se := (*percent.SyntaxError)(nil); errors.As(err, &se) {
+		d.Offset = &se.Offset
+	}
+
+	return d, status
+}
+
+// number returns x, or nil if x cannot be represented in JSON.
+func number(x float64) *float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil
+	}
+
+	return &x
+}
+
+// single returns the handler of a request to e.
+func (s *server) single(e endpoint) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var fields map[string]json.RawMessage
+
+		if err := s.decode(w, r, &fields); err != nil {
+			s.fail(w, err)
+
+			return
+		}
+
+		v, err := call(e, fields)
+		if err != nil {
+			s.fail(w, err)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, response{Result: v, Error: nil})
+	}
+}
+
+// batch returns the handler of a batch of requests to e. Each request succeeds or fails on
+// its own, so the status is OK unless the batch itself is invalid.
+func (s *server) batch(e endpoint) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var batch []map[string]json.RawMessage
+
+		if err := s.decode(w, r, &batch); err != nil {
+			s.fail(w, err)
+
+			return
+		}
+
+		if len(batch) > s.limits.batch {
+			s.fail(w, fmt.Errorf("%w: batch of %d requests exceeds %d", errTooLarge, len(batch), s.limits.batch))
+
+			return
+		}
+
+		out := batchResponse{Results: make([]response, len(batch))}
+		for i, fields := range batch {
+			v, err := call(e, fields)
+			if err != nil {
+				d, _ := details(err)
+				out.Results[i] = response{Result: nil, Error: d}
+
+				continue
+			}
+
+			out.Results[i] = response{Result: v, Error: nil}
+		}
+
+		writeJSON(w, http.StatusOK, out)
+	}
+}
+
+// call returns the result of e for a request with fields.
+func call(e endpoint, fields map[string]json.RawMessage) (any, error) {
+	if fields == nil {
+		return nil, fmt.Errorf("%w: request is not an object", errRequest)
+	}
+
+	a, err := newArgs(e, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []percent.Option
+	if e.options {
+		opts = a.options()
+	}
+
+	v, err := e.call(a, opts)
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// A result that is not finite cannot be encoded in JSON.
+	if x, ok := v.(float64); ok && number(x) == nil {
+		return nil, fmt.Errorf("%w: %s: result %g", percent.ErrNotFinite, e.name, x)
+	}
+
+	return v, nil
+}
+
+// decode decodes the JSON body of r into v, which must be a single value no larger than
+// the limits of s.
+func (s *server) decode(w http.ResponseWriter, r *http.Request, v any) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.limits.body))
+	if err != nil {
+		if mbe := (*http.MaxBytesError)(nil); errors.As(err, &mbe) {
+			return fmt.Errorf("%w: body exceeds %d bytes", errTooLarge, mbe.Limit)
+		}
+
+		return fmt.Errorf("%w: %w", errRequest, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %w", errRequest, err)
+	}
+
+	if dec.More() {
+		return fmt.Errorf("%w: body holds more than one value", errRequest)
+	}
+
+	return nil
+}
+
+// fail writes a response with err.
+func (s *server) fail(w http.ResponseWriter, err error) {
+	d, status := details(err)
+	writeJSON(w, status, response{Result: nil, Error: d})
+}
+
+// document writes the OpenAPI document of the server.
+func (s *server) document(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(s.openAPI)
+}
+
+// notFound writes an error for a path that is not served, or a method that is not allowed
+// on a path that is.
+func (s *server) notFound(w http.ResponseWriter, r *http.Request) {
+	if slices.Contains(s.paths, r.URL.Path) {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, response{Result: nil, Error: &errorDetails{
+			Code: "method_not_allowed", Message: r.Method + " is not allowed on " + r.URL.Path,
+			Op: "", Inputs: nil, Min: nil, Max: nil, Offset: nil,
+		}})
+
+		return
+	}
+
+	writeJSON(w, http.StatusNotFound, response{Result: nil, Error: &errorDetails{
+		Code: "not_found", Message: r.URL.Path + " is not found",
+		Op: "", Inputs: nil, Min: nil, Max: nil, Offset: nil,
+	}})
+}
+
+// writeJSON writes v as the JSON body of a response with status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		data = []byte(`{"error":{"code":"internal","message":"encoding the response failed"}}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(data, '\n'))
+}
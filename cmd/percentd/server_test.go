@@ -0,0 +1,371 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		method string
+		path   string
+		body   string
+	}
+
+	type want struct {
+		status int
+		body   string
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "of",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 8}`},
+			want: want{status: http.StatusOK, body: `{"result": 12.5}`},
+		},
+		{
+			name: "options",
+			in: in{
+				method: http.MethodPost, path: "/v1/of",
+				body: `{"part": 2, "total": 3, "precision": 1, "rounding": "floor"}`,
+			},
+			want: want{status: http.StatusOK, body: `{"result": 66.6}`},
+		},
+		{
+			name: "zero result",
+			in:   in{method: http.MethodPost, path: "/v1/change", body: `{"old": 5, "new": 5}`},
+			want: want{status: http.StatusOK, body: `{"result": 0}`},
+		},
+		{
+			name: "integer",
+			in:   in{method: http.MethodPost, path: "/v1/of-int", body: `{"part": 1, "total": 3}`},
+			want: want{status: http.StatusOK, body: `{"result": 33}`},
+		},
+		{
+			name: "allocate",
+			in: in{
+				method: http.MethodPost, path: "/v1/allocate",
+				body: `{"amount": 100, "percents": [50, 25, 25], "remainder": "first"}`,
+			},
+			want: want{status: http.StatusOK, body: `{"result": [50, 25, 25]}`},
+		},
+		{
+			name: "parse",
+			in:   in{method: http.MethodPost, path: "/v1/parse", body: `{"text": "125‰"}`},
+			want: want{status: http.StatusOK, body: `{"result": 12.5}`},
+		},
+		{
+			name: "divide by zero",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 0}`},
+			want: want{
+				status: http.StatusUnprocessableEntity,
+				body: `{"error": {"code": "divide_by_zero", "message": "pkg percent: division by zero: Of(1, 0)",
+					"op": "Of", "inputs": [1, 0]}}`,
+			},
+		},
+		{
+			name: "out of range",
+			in:   in{method: http.MethodPost, path: "/v1/percent", body: `{"percent": 150, "value": 10}`},
+			want: want{
+				status: http.StatusUnprocessableEntity,
+				body: `{"error": {"code": "out_of_range",
+					"message": "pkg percent: out of the range: Percent(150, 10) not in [0, 100]",
+					"op": "Percent", "inputs": [150, 10], "min": 0, "max": 100}}`,
+			},
+		},
+		{
+			name: "syntax",
+			in:   in{method: http.MethodPost, path: "/v1/parse", body: `{"text": "12.5x"}`},
+			want: want{
+				status: http.StatusUnprocessableEntity,
+				body: `{"error": {"code": "syntax",
+					"message": "pkg percent: invalid syntax: \"12.5x\" at offset 4: unknown unit", "offset": 4}}`,
+			},
+		},
+		{
+			name: "missing field",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1}`},
+			want: want{
+				status: http.StatusBadRequest,
+				body:   `{"error": {"code": "invalid_request", "message": "invalid request: missing field \"total\""}}`,
+			},
+		},
+		{
+			name: "unknown field",
+			in: in{
+				method: http.MethodPost, path: "/v1/round",
+				body: `{"x": 1, "precision": 0, "rounding": "floor", "policy": "clamp"}`,
+			},
+			want: want{
+				status: http.StatusBadRequest,
+				body:   `{"error": {"code": "invalid_request", "message": "invalid request: unknown field \"policy\""}}`,
+			},
+		},
+		{
+			name: "unknown value",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 2, "rounding": "up"}`},
+			want: want{
+				status: http.StatusBadRequest,
+				body: `{"error": {"code": "invalid_request",
+					"message": "invalid request: field \"rounding\": unknown value \"up\""}}`,
+			},
+		},
+		{
+			name: "oversized precision",
+			in: in{
+				method: http.MethodPost, path: "/v1/round",
+				body: `{"x": 1.5, "precision": 10000000, "rounding": "floor"}`,
+			},
+			want: want{
+				status: http.StatusBadRequest,
+				body: `{"error": {"code": "invalid_request",
+					"message": "invalid request: field \"precision\": 10000000 not in [-1, 15]"}}`,
+			},
+		},
+		{
+			name: "negative precision option",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 3, "precision": -2}`},
+			want: want{
+				status: http.StatusBadRequest,
+				body: `{"error": {"code": "invalid_request",
+					"message": "invalid request: field \"precision\": -2 not in [-1, 15]"}}`,
+			},
+		},
+		{
+			name: "unrounded precision option",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 8, "precision": -1}`},
+			want: want{status: http.StatusOK, body: `{"result": 12.5}`},
+		},
+		{
+			name: "malformed body",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 2} {}`},
+			want: want{
+				status: http.StatusBadRequest,
+				body:   `{"error": {"code": "invalid_request", "message": "invalid request: body holds more than one value"}}`,
+			},
+		},
+		{
+			name: "batch",
+			in: in{
+				method: http.MethodPost, path: "/v1/change/batch",
+				body: `[{"old": 100, "new": 150}, {"old": 0, "new": 1}, {"old": 1}]`,
+			},
+			want: want{
+				status: http.StatusOK,
+				body: `{"results": [
+					{"result": 50},
+					{"error": {"code": "divide_by_zero", "message": "pkg percent: division by zero: Change(0, 1)",
+						"op": "Change", "inputs": [0, 1]}},
+					{"error": {"code": "invalid_request", "message": "invalid request: missing field \"new\""}}
+				]}`,
+			},
+		},
+		{
+			name: "batch too large",
+			in:   in{method: http.MethodPost, path: "/v1/of/batch", body: `[{}, {}, {}, {}]`},
+			want: want{
+				status: http.StatusRequestEntityTooLarge,
+				body:   `{"error": {"code": "too_large", "message": "request too large: batch of 4 requests exceeds 3"}}`,
+			},
+		},
+		{
+			name: "body too large",
+			in:   in{method: http.MethodPost, path: "/v1/of", body: `{"part": 1, "total": 2` + strings.Repeat(" ", 256) + `}`},
+			want: want{
+				status: http.StatusRequestEntityTooLarge,
+				body:   `{"error": {"code": "too_large", "message": "request too large: body exceeds 256 bytes"}}`,
+			},
+		},
+		{
+			name: "method not allowed",
+			in:   in{method: http.MethodGet, path: "/v1/of", body: ""},
+			want: want{
+				status: http.StatusMethodNotAllowed,
+				body:   `{"error": {"code": "method_not_allowed", "message": "GET is not allowed on /v1/of"}}`,
+			},
+		},
+		{
+			name: "not found",
+			in:   in{method: http.MethodPost, path: "/v1/times", body: "{}"},
+			want: want{
+				status: http.StatusNotFound,
+				body:   `{"error": {"code": "not_found", "message": "/v1/times is not found"}}`,
+			},
+		},
+	}
+
+	handler, err := newServer(limits{body: 256, batch: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			req := httptest.NewRequest(tt.in.method, tt.in.path, strings.NewReader(tt.in.body))
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != tt.want.status {
+				t.Errorf("%s %s status = %d, want %d", tt.in.method, tt.in.path, rec.Code, tt.want.status)
+			}
+
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("%s %s Content-Type = %q, want application/json", tt.in.method, tt.in.path, ct)
+			}
+
+			if diff := cmp.Diff(decode(t, tt.want.body), decode(t, rec.Body.String())); diff != "" {
+				t.Errorf("%s %s body mismatch (-want +got):\n%s", tt.in.method, tt.in.path, diff)
+			}
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	t.Parallel()
+
+	handler, err := newServer(limits{body: 1 << 20, batch: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range endpoints() {
+		t.Run(e.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			body := map[string]any{}
+			for _, p := range e.params {
+				body[p.name] = sample(p)
+			}
+
+			if e.options {
+				for _, p := range optionParams() {
+					body[p.name] = sample(p)
+				}
+			}
+
+			data, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/"+e.name, strings.NewReader(string(data)))
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != http.StatusOK && rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("POST /v1/%s %s = %d %s, want the parameters accepted", e.name, data, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+// Set UPDATE_GOLDEN=1 to rewrite the golden file in testdata from the current output.
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	handler, err := newServer(limits{body: 1 << 20, batch: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+
+	path := filepath.Join("testdata", "openapi.json")
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var doc struct {
+		Paths map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range endpoints() {
+		for _, p := range []string{"/v1/" + e.name, "/v1/" + e.name + "/batch"} {
+			if _, ok := doc.Paths[p]; !ok {
+				t.Errorf("OpenAPI document lacks path %s", p)
+			}
+		}
+	}
+
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.WriteFile(path, rec.Body.Bytes(), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if diff := cmp.Diff(string(want), rec.Body.String()); diff != "" {
+		t.Errorf("OpenAPI document mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// decode returns the JSON value in s.
+func decode(t *testing.T, s string) any {
+	t.Helper()
+
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("decoding %q: %v", s, err)
+	}
+
+	return v
+}
+
+// sample returns a valid value of p.
+func sample(p param) any {
+	switch p.kind {
+	case kindNumber:
+		return 50
+	case kindInteger, kindPrecision:
+		return 2
+	case kindString:
+		if len(p.enum) > 0 {
+			return p.enum[0]
+		}
+
+		return "12.5%"
+	case kindNumbers, kindIntegers:
+		return []int{50, 50}
+	}
+
+	return nil
+}
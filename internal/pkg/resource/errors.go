@@ -10,4 +10,10 @@ var (
 	ErrOutOfRange           = errors.New(OutOfRangeErrorMessage)
 	ErrDivideByZero         = errors.New(DivideByZeroErrorMessage)
 	ErrPartGreaterThanTotal = errors.New(PartGreaterThanTotalErrorMessage)
+	ErrSyntax               = errors.New(SyntaxErrorMessage)
+	ErrSumMismatch          = errors.New(SumMismatchErrorMessage)
+	ErrNotFinite            = errors.New(NotFiniteErrorMessage)
+	ErrOverflow             = errors.New(OverflowErrorMessage)
+	ErrUndefined            = errors.New(UndefinedErrorMessage)
+	ErrLengthMismatch       = errors.New(LengthMismatchErrorMessage)
 )
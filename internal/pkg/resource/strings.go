@@ -6,4 +6,40 @@ const (
 	OutOfRangeErrorMessage           = "pkg percent: out of the range"
 	DivideByZeroErrorMessage         = "pkg percent: division by zero"
 	PartGreaterThanTotalErrorMessage = "pkg percent: part cannot be greater than total"
+	SyntaxErrorMessage               = "pkg percent: invalid syntax"
+	SumMismatchErrorMessage          = "pkg percent: percentages do not sum to 100"
+	NotFiniteErrorMessage            = "pkg percent: not a finite number"
+	OverflowErrorMessage             = "pkg percent: integer overflow"
+	UndefinedErrorMessage            = "pkg percent: undefined name"
+	LengthMismatchErrorMessage       = "pkg percent: slice lengths do not match"
+)
+
+const (
+	OperationErrorFormat      = "%v: %s(%s)"
+	OperationRangeErrorFormat = "%v: %s(%s) not in [%g, %g]"
+	SliceErrorFormat          = "%s: %d of %d elements failed, first at index %d: %v"
+)
+
+const (
+	UnsupportedTypeErrorFormat = "%w: %s: unsupported type %T"
+	EnvErrorFormat             = "environment variable %s: %w"
+	FlagUsageFormat            = "%s, a `%s` in [%g, %g] such as %s"
+)
+
+const (
+	SyntaxErrorFormat = "%v: %q at offset %d: %s"
+
+	EmptyInputMessage          = "empty input"
+	ExpectedDigitMessage       = "expected digit"
+	UnexpectedCharacterMessage = "unexpected character"
+	UnknownUnitMessage         = "unknown unit"
+	InvalidLengthMessage       = "invalid length"
+	InvalidNumberMessage       = "invalid number"
+	InvalidGroupingMessage     = "invalid digit grouping"
+
+	UnexpectedTokenFormat = "unexpected %s"
+	ExpectedTokenFormat   = "expected %s, found %s"
+	UndefinedErrorFormat  = "%w: %q at offset %d"
+	UnknownNodeFormat     = "%w: unknown node %T"
+	UnknownFunctionFormat = "%w: unknown function %v"
 )
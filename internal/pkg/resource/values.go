@@ -6,3 +6,19 @@ const (
 	PercentMin = 0.0
 	PercentMax = 100.0
 )
+
+const (
+	RatioMin = 0.0
+	RatioMax = 1.0
+)
+
+const (
+	BasisPointsMax = 10000.0
+	PerMilleMax    = 1000.0
+	PPMMax         = 1000000.0
+)
+
+const (
+	DecimalsMin = 0
+	DecimalsMax = 15
+)
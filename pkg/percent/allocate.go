@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// RemainderPolicy selects which splits receive the minor units that are left over when
+// Allocate rounds every split toward zero.
+type RemainderPolicy int
+
+const (
+	// RemainderToLargest hands one leftover unit each to the splits with the largest
+	// remainders. Equal remainders are resolved in favor of the earlier split.
+	RemainderToLargest RemainderPolicy = iota
+	// RemainderToFirst hands all leftover units to the first split with a non-zero percentage.
+	RemainderToFirst
+	// RemainderToLast hands all leftover units to the last split with a non-zero percentage.
+	RemainderToLast
+	// RemainderRoundRobin hands one leftover unit each to the splits with a non-zero
+	// percentage, in order.
+	RemainderRoundRobin
+)
+
+// Allocate splits amount, given in integer minor units such as cents, by percents. The splits
+// sum to exactly amount; leftover units go to the splits with the largest remainders.
+//
+// Allocate returns an *Error wrapping ErrNotFinite if a percentage is NaN or infinite, one
+// wrapping ErrOutOfRange if a percentage is negative, and one wrapping ErrSumMismatch if the
+// percentages do not sum to exactly 100.
+func Allocate(amount int64, percents []Percentage) ([]int64, error) {
+	return AllocateWith(amount, percents, RemainderToLargest)
+}
+
+// AllocateWith is like Allocate but hands leftover units to the splits chosen by policy.
+//
+// Percentages are read as the decimal numbers they print as, so 33.33, 33.33 and 33.34 sum to
+// exactly 100. A negative amount is split like its absolute value, with every split negated.
+//
+// AllocateWith returns an *Error wrapping ErrOutOfRange if policy is not one of the
+// RemainderPolicy constants, and the errors of Allocate otherwise.
+func AllocateWith(amount int64, percents []Percentage, policy RemainderPolicy) ([]int64, error) {
+	if policy < RemainderToLargest || policy > RemainderRoundRobin {
+		return nil, &Error{
+			Op: "Allocate", Inputs: []float64{float64(policy)}, Min: float64(RemainderToLargest),
+			Max: float64(RemainderRoundRobin), Err: ErrOutOfRange,
+		}
+	}
+
+	sum := new(big.Rat)
+	quotas := make([]*big.Rat, len(percents))
+	hundred := big.NewRat(int64(resource.PercentMax), 1)
+
+	for i, p := range percents {
+		f := float64(p)
+		if err := checkFinite("Allocate", f); err != nil {
+			return nil, err
+		}
+
+		if f < 0 {
+			return nil, &Error{
+				Op: "Allocate", Inputs: []float64{f}, Min: resource.PercentMin,
+				Max: resource.PercentMax, Err: ErrOutOfRange,
+			}
+		}
+
+		quotas[i], _ = new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+		sum.Add(sum, quotas[i])
+	}
+
+	if sum.Cmp(hundred) != 0 {
+		s, _ := sum.Float64()
+
+		return nil, &Error{
+			Op: "Allocate", Inputs: []float64{s}, Min: resource.PercentMax,
+			Max: resource.PercentMax, Err: ErrSumMismatch,
+		}
+	}
+
+	abs := new(big.Int).Abs(big.NewInt(amount))
+	wholes := make([]*big.Int, len(percents))
+	remainders := make([]*big.Rat, len(percents))
+	left := new(big.Int).Set(abs)
+
+	for i, q := range quotas {
+		q.Mul(q, new(big.Rat).SetInt(abs))
+		q.Quo(q, hundred)
+
+		wholes[i] = new(big.Int).Quo(q.Num(), q.Denom())
+		remainders[i] = q.Sub(q, new(big.Rat).SetInt(wholes[i]))
+		left.Sub(left, wholes[i])
+	}
+
+	one := big.NewInt(1)
+	for _, i := range remainderOrder(percents, remainders, policy, left.Int64()) {
+		wholes[i].Add(wholes[i], one)
+	}
+
+	splits := make([]int64, len(percents))
+	for i, w := range wholes {
+		if amount < 0 {
+			w.Neg(w)
+		}
+
+		splits[i] = w.Int64()
+	}
+
+	return splits, nil
+}
+
+// remainderOrder returns the indices of the splits that receive one of the left leftover units,
+// as chosen by policy. An index appears once per unit it receives.
+func remainderOrder(
+	percents []Percentage, remainders []*big.Rat, policy RemainderPolicy, left int64,
+) []int {
+	eligible := make([]int, 0, len(percents))
+	for i, p := range percents {
+		if p != 0 {
+			eligible = append(eligible, i)
+		}
+	}
+
+	if left == 0 || len(eligible) == 0 {
+		return nil
+	}
+
+	order := make([]int, 0, left)
+
+	switch policy {
+	case RemainderToFirst:
+		for range left {
+			order = append(order, eligible[0])
+		}
+	case RemainderToLast:
+		for range left {
+			order = append(order, eligible[len(eligible)-1])
+		}
+	case RemainderRoundRobin:
+		for k := range left {
+			order = append(order, eligible[k%int64(len(eligible))])
+		}
+	case RemainderToLargest:
+		slices.SortStableFunc(eligible, func(a, b int) int {
+			return remainders[b].Cmp(remainders[a])
+		})
+		order = append(order, eligible[:left]...)
+	}
+
+	return order
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestAllocateWith(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		amount   int64
+		percents []percent.Percentage
+		policy   percent.RemainderPolicy
+	}
+
+	type want struct {
+		value []int64
+		err   error
+	}
+
+	thirds := []percent.Percentage{33.33, 33.33, 33.34}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "exact split",
+			in:   in{amount: 1000, percents: []percent.Percentage{50, 30, 20}, policy: percent.RemainderToLargest},
+			want: want{value: []int64{500, 300, 200}, err: nil},
+		},
+		{
+			name: "largest remainder",
+			in:   in{amount: 100, percents: thirds, policy: percent.RemainderToLargest},
+			want: want{value: []int64{33, 33, 34}, err: nil},
+		},
+		{
+			name: "largest remainder ties favor earlier splits",
+			in:   in{amount: 5, percents: []percent.Percentage{25, 25, 25, 25}, policy: percent.RemainderToLargest},
+			want: want{value: []int64{2, 1, 1, 1}, err: nil},
+		},
+		{
+			name: "first gets the remainder",
+			in:   in{amount: 11, percents: []percent.Percentage{0, 25, 25, 50}, policy: percent.RemainderToFirst},
+			want: want{value: []int64{0, 4, 2, 5}, err: nil},
+		},
+		{
+			name: "last gets the remainder",
+			in:   in{amount: 7, percents: []percent.Percentage{25, 25, 25, 25}, policy: percent.RemainderToLast},
+			want: want{value: []int64{1, 1, 1, 4}, err: nil},
+		},
+		{
+			name: "round robin",
+			in:   in{amount: 7, percents: []percent.Percentage{0, 25, 25, 50}, policy: percent.RemainderRoundRobin},
+			want: want{value: []int64{0, 2, 2, 3}, err: nil},
+		},
+		{
+			name: "negative amount",
+			in:   in{amount: -100, percents: thirds, policy: percent.RemainderToLargest},
+			want: want{value: []int64{-33, -33, -34}, err: nil},
+		},
+		{
+			name: "extreme amount",
+			in:   in{amount: math.MinInt64, percents: []percent.Percentage{100}, policy: percent.RemainderToLargest},
+			want: want{value: []int64{math.MinInt64}, err: nil},
+		},
+		{
+			name: "zero amount",
+			in:   in{amount: 0, percents: thirds, policy: percent.RemainderRoundRobin},
+			want: want{value: []int64{0, 0, 0}, err: nil},
+		},
+		{
+			name: "sum below 100",
+			in:   in{amount: 100, percents: []percent.Percentage{33.33, 33.33, 33.33}, policy: percent.RemainderToLargest},
+			want: want{value: nil, err: percent.ErrSumMismatch},
+		},
+		{
+			name: "empty percents",
+			in:   in{amount: 100, percents: nil, policy: percent.RemainderToLargest},
+			want: want{value: nil, err: percent.ErrSumMismatch},
+		},
+		{
+			name: "negative percent",
+			in:   in{amount: 100, percents: []percent.Percentage{110, -10}, policy: percent.RemainderToLargest},
+			want: want{value: nil, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "not a number",
+			in:   in{amount: 100, percents: []percent.Percentage{percent.Percentage(math.NaN())}, policy: percent.RemainderToLargest},
+			want: want{value: nil, err: percent.ErrNotFinite},
+		},
+		{
+			name: "unknown policy",
+			in:   in{amount: 7, percents: []percent.Percentage{25, 25, 25, 25}, policy: percent.RemainderRoundRobin + 1},
+			want: want{value: nil, err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.AllocateWith(tt.in.amount, tt.in.percents, tt.in.policy)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("AllocateWith() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("AllocateWith(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	percents := []percent.Percentage{12.5, 37.5, 50}
+
+	// Act
+	got, err := percent.Allocate(999, percents)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+	if want := []int64{125, 375, 499}; !cmp.Equal(got, want) {
+		t.Errorf("Allocate(999, %v) = %v, want %v", percents, got, want)
+	}
+}
+
+func FuzzAllocate(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		amount int64
+		a, b   uint16
+	}{
+		{100, 3333, 3333},     // thirds
+		{-1, 5000, 2500},      // negative amount
+		{0, 10000, 0},         // zero amount
+		{math.MaxInt64, 1, 1}, // largest amount
+		{math.MinInt64, 0, 0}, // smallest amount
+		{12345, 9999, 1},      // tiny share
+	}
+	for _, tc := range testcases {
+		f.Add(tc.amount, tc.a, tc.b) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, amount int64, a, b uint16) {
+		// Arrange
+		// Shares are given in basis points so the three percentages sum to exactly 100.
+		x, y := int(a)%10001, int(b)%10001
+		if x+y > 10000 {
+			y = 10000 - x
+		}
+		percents := []percent.Percentage{
+			percent.BasisPoints(x).Percentage(),
+			percent.BasisPoints(y).Percentage(),
+			percent.BasisPoints(10000 - x - y).Percentage(),
+		}
+
+		policies := []percent.RemainderPolicy{
+			percent.RemainderToLargest,
+			percent.RemainderToFirst,
+			percent.RemainderToLast,
+			percent.RemainderRoundRobin,
+		}
+		for _, policy := range policies {
+			// Act
+			got, err := percent.AllocateWith(amount, percents, policy)
+
+			// Assert
+			// Property 1: Function should never panic
+			// Property 2: Splits sum to exactly the amount
+			// Property 3: Every split has the sign of the amount
+
+			if err != nil {
+				t.Fatalf("AllocateWith(%v, %v) returned unexpected error: %v", amount, percents, err)
+			}
+			var sum int64
+			for _, split := range got {
+				sum += split
+				if (amount >= 0 && split < 0) || (amount <= 0 && split > 0) {
+					t.Errorf("AllocateWith(%v, %v) = %v, split with wrong sign", amount, percents, got)
+				}
+			}
+			if sum != amount {
+				t.Errorf("AllocateWith(%v, %v, %v) = %v, sum %v", amount, percents, policy, got, sum)
+			}
+		}
+	})
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// Apply returns p percent of value in the type of value, so that it also works with named
+// types such as time.Duration: Apply(150, 2*time.Second) is 3s. Unlike Percent, p may be
+// negative or exceed 100.
+//
+// For integer types the result is computed exactly from the decimal value of p, so 12.5
+// percent is exactly one eighth, and rounded to a whole number using the mode selected by
+// WithRounding. For floating-point types the result is rounded as WithPrecision selects.
+//
+// Apply returns an *Error wrapping ErrNotFinite if p, value or the result is NaN or infinite,
+// and one wrapping ErrOverflow if the result does not fit in T. With WithSaturation, such
+// results are clamped to the bounds of T instead. AllowNonFinite only applies to
+// floating-point types.
+func Apply[T constraints.Integer | constraints.Float](p Percentage, value T, opts ...Option) (T, error) {
+	o := newOptions(opts)
+
+	f, v := float64(p), float64(value)
+	if !isInteger[T]() {
+		return applyFloat(f, value, o)
+	}
+
+	if err := checkFinite("Apply", f, v); err != nil {
+		return 0, err
+	}
+
+	m, neg := magnitude(value)
+
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(m)))
+	r.Quo(r, big.NewRat(resource.PercentMax, 1))
+
+	if neg {
+		r.Neg(r)
+	}
+
+	q := roundRat(r, o.rounding)
+	if q.BitLen() <= 64 {
+		if x, ok := fromMagnitude[T](new(big.Int).Abs(q).Uint64(), q.Sign() < 0); ok {
+			return x, nil
+		}
+	}
+
+	return overflow[T]("Apply", q.Sign() < 0, o, f, v)
+}
+
+// applyFloat returns p percent of the floating-point value.
+func applyFloat[T constraints.Integer | constraints.Float](p float64, value T, o options) (T, error) {
+	v := float64(value)
+	if err := o.finite("Apply", p, v); err != nil {
+		return 0, err
+	}
+
+	x, err := o.result("Apply", v*(p/resource.PercentMax), p, v)
+	if err != nil {
+		return 0, err
+	}
+
+	// A finite float64 result may still exceed the range of float32.
+	if r := T(x); finite(x) && !finite(float64(r)) {
+		if !o.saturate {
+			return 0, newError("Apply", ErrOverflow, p, v)
+		}
+
+		return T(math.Copysign(math.MaxFloat32, x)), nil
+	}
+
+	return T(x), nil
+}
+
+// isInteger reports whether T is an integer type, in which case dividing one by two is zero.
+func isInteger[T constraints.Integer | constraints.Float]() bool {
+	var one T = 1
+
+	return one/2 == 0
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call func() (any, error)
+	}
+
+	type want struct {
+		value any
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "duration",
+			in: in{call: func() (any, error) {
+				return percent.Apply(150, 2*time.Second)
+			}},
+			want: want{value: 3 * time.Second, err: nil},
+		},
+		{
+			name: "duration rounds to nanoseconds",
+			in: in{call: func() (any, error) {
+				return percent.Apply(50, time.Duration(3))
+			}},
+			want: want{value: time.Duration(2), err: nil},
+		},
+		{
+			name: "duration with rounding mode",
+			in: in{call: func() (any, error) {
+				return percent.Apply(50, time.Duration(5), percent.WithRounding(percent.HalfEven))
+			}},
+			want: want{value: time.Duration(2), err: nil},
+		},
+		{
+			name: "decimal percentage is exact",
+			in: in{call: func() (any, error) {
+				return percent.Apply(12.5, int64(1<<60+8))
+			}},
+			want: want{value: int64(1<<57 + 1), err: nil},
+		},
+		{
+			name: "negative percentage",
+			in: in{call: func() (any, error) {
+				return percent.Apply(-10, 250)
+			}},
+			want: want{value: -25, err: nil},
+		},
+		{
+			name: "truncated integer",
+			in: in{call: func() (any, error) {
+				return percent.Apply(33, uint8(10), percent.WithRounding(percent.Truncate))
+			}},
+			want: want{value: uint8(3), err: nil},
+		},
+		{
+			name: "integer overflow",
+			in: in{call: func() (any, error) {
+				return percent.Apply(200, int8(100))
+			}},
+			want: want{value: int8(0), err: percent.ErrOverflow},
+		},
+		{
+			name: "integer saturates to maximum",
+			in: in{call: func() (any, error) {
+				return percent.Apply(200, int8(100), percent.WithSaturation())
+			}},
+			want: want{value: int8(127), err: nil},
+		},
+		{
+			name: "unsigned saturates to zero",
+			in: in{call: func() (any, error) {
+				return percent.Apply(-50, uint16(10), percent.WithSaturation())
+			}},
+			want: want{value: uint16(0), err: nil},
+		},
+		{
+			name: "float with precision",
+			in: in{call: func() (any, error) {
+				return percent.Apply(100.0/3, 1.0, percent.WithPrecision(2))
+			}},
+			want: want{value: 0.33, err: nil},
+		},
+		{
+			name: "float32 overflow",
+			in: in{call: func() (any, error) {
+				return percent.Apply(200, float32(math.MaxFloat32))
+			}},
+			want: want{value: float32(0), err: percent.ErrOverflow},
+		},
+		{
+			name: "float32 saturates",
+			in: in{call: func() (any, error) {
+				return percent.Apply(-200, float32(math.MaxFloat32), percent.WithSaturation())
+			}},
+			want: want{value: float32(-math.MaxFloat32), err: nil},
+		},
+		{
+			name: "not a number",
+			in: in{call: func() (any, error) {
+				return percent.Apply(percent.Percentage(math.NaN()), time.Second)
+			}},
+			want: want{value: time.Duration(0), err: percent.ErrNotFinite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call()
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Apply() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("Apply() = %v (%T), want %v (%T)", got, got, tt.want.value, tt.want.value)
+			}
+		})
+	}
+}
+
+func FuzzApply(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		percent float64
+		value   int64
+	}{
+		{50, int64(time.Second)}, // half a second
+		{100, math.MaxInt64},     // largest value
+		{100, math.MinInt64},     // smallest value
+		{250, math.MaxInt64},     // overflow (should error)
+		{-0.5, 12345},            // negative percent
+		{1e-300, 1},              // tiny percent
+	}
+	for _, tc := range testcases {
+		f.Add(tc.percent, tc.value) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, pct float64, value int64) {
+		// Arrange
+		d := time.Duration(value)
+
+		// Act
+		got, err := percent.Apply(percent.Percentage(pct), d)
+		saturated, serr := percent.Apply(percent.Percentage(pct), d, percent.WithSaturation())
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: If percent is NaN or infinite, should return ErrNotFinite
+		// Property 3: Saturation only changes results that overflow
+		// Property 4: If no error, result is within one unit of the float64 estimate
+
+		if math.IsNaN(pct) || math.IsInf(pct, 0) {
+			if !errors.Is(err, percent.ErrNotFinite) || !errors.Is(serr, percent.ErrNotFinite) {
+				t.Errorf("Apply(%v, %v) error = %v, %v, want ErrNotFinite", pct, d, err, serr)
+			}
+
+			return
+		}
+		if errors.Is(err, percent.ErrOverflow) {
+			if serr != nil || (saturated != math.MaxInt64 && saturated != math.MinInt64) {
+				t.Errorf("Apply(%v, %v) saturated = %v, %v, want a bound", pct, d, saturated, serr)
+			}
+
+			return
+		}
+		if err != nil || serr != nil || got != saturated {
+			t.Fatalf("Apply(%v, %v) = %v, %v; saturated %v, %v", pct, d, got, err, saturated, serr)
+		}
+		estimate := float64(value) * pct / 100
+		if diff := math.Abs(float64(got) - estimate); diff > 1 && diff > math.Abs(estimate)*1e-15 {
+			t.Errorf("Apply(%v, %v) = %v, want about %v", pct, d, got, estimate)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"slices"
+)
+
+// Calculator bundles options, such as a Policy and a precision, and applies them to every
+// operation. It is safe for concurrent use. The zero value applies the defaults.
+type Calculator struct {
+	opts []Option
+}
+
+// NewCalculator returns a Calculator that applies opts to every operation.
+func NewCalculator(opts ...Option) Calculator {
+	return Calculator{opts: slices.Clone(opts)}
+}
+
+// Options returns the options of c, for use with the generic functions of the package such as
+// Apply or PercentInt. Options given to a call after them take precedence.
+func (c Calculator) Options() []Option {
+	return slices.Clip(c.opts)
+}
+
+// Percent is like the function Percent, with the options of c followed by opts.
+func (c Calculator) Percent(percent, value float64, opts ...Option) (float64, error) {
+	return Percent(percent, value, c.with(opts)...)
+}
+
+// Of is like the function Of, with the options of c followed by opts.
+func (c Calculator) Of(part, total float64, opts ...Option) (float64, error) {
+	return Of(part, total, c.with(opts)...)
+}
+
+// Change is like the function Change, with the options of c followed by opts.
+func (c Calculator) Change(oldValue, newValue float64, opts ...Option) (float64, error) {
+	return Change(oldValue, newValue, c.with(opts)...)
+}
+
+// Remain is like the function Remain, with the options of c followed by opts.
+func (c Calculator) Remain(percent, value float64, opts ...Option) (float64, error) {
+	return Remain(percent, value, c.with(opts)...)
+}
+
+// FromRatio is like the function FromRatio, with the options of c followed by opts.
+func (c Calculator) FromRatio(ratio float64, opts ...Option) (float64, error) {
+	return FromRatio(ratio, c.with(opts)...)
+}
+
+// ToRatio is like the function ToRatio, with the options of c followed by opts.
+func (c Calculator) ToRatio(percent float64, opts ...Option) (float64, error) {
+	return ToRatio(percent, c.with(opts)...)
+}
+
+// with returns the options of c followed by opts.
+func (c Calculator) with(opts []Option) []Option {
+	if len(opts) == 0 {
+		return c.opts
+	}
+
+	return append(c.Options(), opts...)
+}
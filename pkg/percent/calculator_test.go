@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestCalculator(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		calc percent.Calculator
+		call func(c percent.Calculator) (float64, error)
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	quota := percent.NewCalculator(percent.WithPolicy(percent.AllowUnbounded), percent.WithPrecision(1))
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "zero value uses defaults",
+			in: in{
+				calc: percent.Calculator{},
+				call: func(c percent.Calculator) (float64, error) { return c.Of(130, 100) },
+			},
+			want: want{value: 0, err: percent.ErrPartGreaterThanTotal},
+		},
+		{
+			name: "of",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) { return c.Of(400, 300) },
+			},
+			want: want{value: 133.3, err: nil},
+		},
+		{
+			name: "percent",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) { return c.Percent(150, 20.01) },
+			},
+			want: want{value: 30, err: nil},
+		},
+		{
+			name: "change",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) { return c.Change(3, 4) },
+			},
+			want: want{value: 33.3, err: nil},
+		},
+		{
+			name: "remain",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) { return c.Remain(110, 10) },
+			},
+			want: want{value: -1, err: nil},
+		},
+		{
+			name: "from ratio",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) { return c.FromRatio(1.2346) },
+			},
+			want: want{value: 123.5, err: nil},
+		},
+		{
+			name: "to ratio",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) { return c.ToRatio(120) },
+			},
+			want: want{value: 1.2, err: nil},
+		},
+		{
+			name: "call options take precedence",
+			in: in{
+				calc: quota,
+				call: func(c percent.Calculator) (float64, error) {
+					return c.Of(400, 300, percent.WithPolicy(percent.Strict))
+				},
+			},
+			want: want{value: 0, err: percent.ErrPartGreaterThanTotal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call(tt.in.calc)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("got %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestCalculatorOptions(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	c := percent.NewCalculator(percent.WithPolicy(percent.AllowUnbounded))
+
+	// Act
+	got, err := percent.Apply(120, 10*time.Second, c.Options()...)
+	_, _ = c.Of(1, 1, percent.WithPrecision(2))
+	again, _ := c.Of(1, 3)
+
+	// Assert
+	if err != nil || got != 12*time.Second {
+		t.Errorf("Apply() = %v, %v, want 12s", got, err)
+	}
+	if again == 33.33 {
+		t.Errorf("Of() = %v, options of an earlier call leaked into the calculator", again)
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// ZeroBaseline selects the result of Change, LogChange and PercentMore when the old value is
+// zero, where the relative change is undefined.
+type ZeroBaseline int
+
+const (
+	// ZeroBaselineError returns an error wrapping ErrDivideByZero. It is the default.
+	ZeroBaselineError ZeroBaseline = iota
+	// ZeroBaselineInf returns +Inf or -Inf with the sign of the change, and 0 if the new value
+	// is zero as well.
+	ZeroBaselineInf
+	// ZeroBaselineNaN returns NaN and no error, marking the change as undefined, like a null
+	// value in a report.
+	ZeroBaselineNaN
+	// zeroBaselineEpsilon divides by the epsilon given to WithEpsilon instead of zero.
+	zeroBaselineEpsilon
+)
+
+// String returns the name of z, e.g. "inf".
+func (z ZeroBaseline) String() string {
+	switch z {
+	case ZeroBaselineError:
+		return "error"
+	case ZeroBaselineInf:
+		return "inf"
+	case ZeroBaselineNaN:
+		return "nan"
+	case zeroBaselineEpsilon:
+		return "epsilon"
+	}
+
+	return "ZeroBaseline(" + strconv.Itoa(int(z)) + ")"
+}
+
+// WithZeroBaseline selects how Change, LogChange and PercentMore handle an old value of zero.
+// The results of ZeroBaselineInf and ZeroBaselineNaN are returned even without AllowNonFinite.
+func WithZeroBaseline(z ZeroBaseline) Option {
+	return func(o *options) {
+		o.zero = z
+	}
+}
+
+// WithEpsilon makes Change, LogChange and PercentMore use eps, which should be positive, as the
+// old value when it is zero. It replaces the handling selected by WithZeroBaseline.
+func WithEpsilon(eps float64) Option {
+	return func(o *options) {
+		o.zero = zeroBaselineEpsilon
+		o.epsilon = eps
+	}
+}
+
+// LogChange returns the logarithmic change ln(new/old)*100 between two values, in log points.
+// Unlike Change, log changes are symmetric and add up: a rise from 100 to 200 is +69.3 and the
+// fall back to 100 is -69.3.
+//
+// Both values must have the same sign; for negative values the change is that of their
+// magnitudes, so a move from -100 to -200 is +69.3. A new value of zero yields -Inf, which is
+// rejected with ErrNotFinite unless AllowNonFinite is given.
+//
+// LogChange returns an *Error wrapping ErrOutOfRange if the values have opposite signs, and
+// one wrapping ErrDivideByZero if oldValue is zero, unless WithZeroBaseline or WithEpsilon
+// selects another result.
+func LogChange[T constraints.Integer | constraints.Float](
+	oldValue, newValue T, opts ...Option,
+) (float64, error) {
+	o := newOptions(opts)
+
+	old, n := float64(oldValue), float64(newValue)
+	if err := o.finite("LogChange", old, n); err != nil {
+		return 0, err
+	}
+
+	base := old
+	if old == 0 {
+		// Growth from nothing has the sign of the magnitude, which is never negative.
+		if r, ok, err := o.zeroBaseline("LogChange", math.Abs(n), old, n); ok {
+			return r, err
+		}
+
+		base = math.Copysign(o.epsilon, n)
+	}
+
+	ratio := n / base
+	if ratio < 0 {
+		return 0, &Error{
+			Op: "LogChange", Inputs: []float64{old, n}, Min: 0, Max: math.Inf(1), Err: ErrOutOfRange,
+		}
+	}
+
+	return o.result("LogChange", math.Log(ratio)*resource.PercentMax, old, n)
+}
+
+// SymmetricChange returns the symmetric percent difference between two values, the
+// difference relative to the mean of their magnitudes:
+//
+//	(new - old) / ((|old| + |new|) / 2) * 100
+//
+// The result lies within [-200, 200], has the sign of new-old as Change does, and is defined
+// for a zero old value. SymmetricChange returns 0 if both values are zero.
+func SymmetricChange[T constraints.Integer | constraints.Float](
+	oldValue, newValue T, opts ...Option,
+) (float64, error) {
+	o := newOptions(opts)
+
+	old, n := float64(oldValue), float64(newValue)
+	if err := o.finite("SymmetricChange", old, n); err != nil {
+		return 0, err
+	}
+
+	mean := (math.Abs(old) + math.Abs(n)) / 2
+	if mean == 0 {
+		return 0, nil
+	}
+
+	return o.result("SymmetricChange", (n-old)/mean*resource.PercentMax, old, n)
+}
+
+// PointChange returns the difference between two percentages in percentage points: a rise
+// from 4% to 5% is 1 point, while Change reports it as 25%. Both percentages are validated
+// according to the Policy.
+func PointChange[T constraints.Integer | constraints.Float](
+	oldPercent, newPercent T, opts ...Option,
+) (float64, error) {
+	o := newOptions(opts)
+
+	p, q := float64(oldPercent), float64(newPercent)
+	if err := o.finite("PointChange", p, q); err != nil {
+		return 0, err
+	}
+
+	old, err := o.bound("PointChange", p, resource.PercentMin, resource.PercentMax, p, q)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := o.bound("PointChange", q, resource.PercentMin, resource.PercentMax, p, q)
+	if err != nil {
+		return 0, err
+	}
+
+	return o.result("PointChange", n-old, p, q)
+}
+
+// zeroBaseline returns the result of op for a zero old value according to o, where sign is
+// the sign of the change. It reports false if op should divide by the epsilon of o instead.
+func (o *options) zeroBaseline(op string, sign float64, inputs ...float64) (float64, bool, error) {
+	switch o.zero {
+	case zeroBaselineEpsilon:
+		return 0, false, nil
+	case ZeroBaselineInf:
+		if sign == 0 {
+			return 0, true, nil
+		}
+
+		return math.Inf(int(math.Copysign(1, sign))), true, nil
+	case ZeroBaselineNaN:
+		return math.NaN(), true, nil
+	case ZeroBaselineError:
+	}
+
+	return 0, true, newError(op, ErrDivideByZero, inputs...)
+}
@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// approx compares floats within a relative tolerance and treats NaNs as equal.
+func approx() cmp.Option {
+	return cmp.Comparer(func(a, b float64) bool {
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return math.IsNaN(a) && math.IsNaN(b)
+		}
+
+		return a == b || math.Abs(a-b) <= 1e-12*math.Max(math.Abs(a), math.Abs(b))
+	})
+}
+
+func TestLogChange(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		oldValue float64
+		newValue float64
+		opts     []percent.Option
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "doubling",
+			in:   in{oldValue: 100, newValue: 200, opts: nil},
+			want: want{value: 100 * math.Ln2, err: nil},
+		},
+		{
+			name: "halving is symmetric",
+			in:   in{oldValue: 200, newValue: 100, opts: nil},
+			want: want{value: -100 * math.Ln2, err: nil},
+		},
+		{
+			name: "no change",
+			in:   in{oldValue: 42, newValue: 42, opts: nil},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "negative values compare magnitudes",
+			in:   in{oldValue: -100, newValue: -200, opts: nil},
+			want: want{value: 100 * math.Ln2, err: nil},
+		},
+		{
+			name: "opposite signs",
+			in:   in{oldValue: -1, newValue: 2, opts: nil},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "new value of zero",
+			in:   in{oldValue: 1, newValue: 0, opts: nil},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+		{
+			name: "new value of zero allowed",
+			in:   in{oldValue: 1, newValue: 0, opts: []percent.Option{percent.AllowNonFinite()}},
+			want: want{value: math.Inf(-1), err: nil},
+		},
+		{
+			name: "zero baseline",
+			in:   in{oldValue: 0, newValue: 5, opts: nil},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "zero baseline to infinity",
+			in:   in{oldValue: 0, newValue: -5, opts: []percent.Option{percent.WithZeroBaseline(percent.ZeroBaselineInf)}},
+			want: want{value: math.Inf(1), err: nil},
+		},
+		{
+			name: "zero baseline with epsilon",
+			in:   in{oldValue: 0, newValue: -2, opts: []percent.Option{percent.WithEpsilon(1)}},
+			want: want{value: 100 * math.Ln2, err: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.LogChange(tt.in.oldValue, tt.in.newValue, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("LogChange() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value, approx()) {
+				t.Errorf("LogChange(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestSymmetricChange(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		oldValue float64
+		newValue float64
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "increase",
+			in:   in{oldValue: 100, newValue: 150},
+			want: want{value: 40, err: nil},
+		},
+		{
+			name: "decrease mirrors increase",
+			in:   in{oldValue: 150, newValue: 100},
+			want: want{value: -40, err: nil},
+		},
+		{
+			name: "zero baseline",
+			in:   in{oldValue: 0, newValue: 7},
+			want: want{value: 200, err: nil},
+		},
+		{
+			name: "both zero",
+			in:   in{oldValue: 0, newValue: 0},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "negative values follow the direction",
+			in:   in{oldValue: -100, newValue: -50},
+			want: want{value: 50 / 75.0 * 100, err: nil},
+		},
+		{
+			name: "not a number",
+			in:   in{oldValue: math.NaN(), newValue: 1},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.SymmetricChange(tt.in.oldValue, tt.in.newValue)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("SymmetricChange() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value, approx()) {
+				t.Errorf("SymmetricChange(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestPointChange(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		oldPercent float64
+		newPercent float64
+		opts       []percent.Option
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "rise",
+			in:   in{oldPercent: 4, newPercent: 5, opts: nil},
+			want: want{value: 1, err: nil},
+		},
+		{
+			name: "fall",
+			in:   in{oldPercent: 12.5, newPercent: 10, opts: nil},
+			want: want{value: -2.5, err: nil},
+		},
+		{
+			name: "percent over 100",
+			in:   in{oldPercent: 90, newPercent: 130, opts: nil},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "percent over 100 allowed",
+			in:   in{oldPercent: 90, newPercent: 130, opts: []percent.Option{percent.WithPolicy(percent.AllowUnbounded)}},
+			want: want{value: 40, err: nil},
+		},
+		{
+			name: "percent over 100 clamped",
+			in:   in{oldPercent: 90, newPercent: 130, opts: []percent.Option{percent.WithPolicy(percent.Clamp)}},
+			want: want{value: 10, err: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.PointChange(tt.in.oldPercent, tt.in.newPercent, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("PointChange() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("PointChange(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestChangeZeroBaseline(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		newValue float64
+		opts     []percent.Option
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "error by default",
+			in:   in{newValue: 5, opts: nil},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "positive infinity",
+			in:   in{newValue: 5, opts: []percent.Option{percent.WithZeroBaseline(percent.ZeroBaselineInf)}},
+			want: want{value: math.Inf(1), err: nil},
+		},
+		{
+			name: "negative infinity",
+			in:   in{newValue: -5, opts: []percent.Option{percent.WithZeroBaseline(percent.ZeroBaselineInf)}},
+			want: want{value: math.Inf(-1), err: nil},
+		},
+		{
+			name: "no change from zero",
+			in:   in{newValue: 0, opts: []percent.Option{percent.WithZeroBaseline(percent.ZeroBaselineInf)}},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "not a number",
+			in:   in{newValue: 5, opts: []percent.Option{percent.WithZeroBaseline(percent.ZeroBaselineNaN)}},
+			want: want{value: math.NaN(), err: nil},
+		},
+		{
+			name: "epsilon",
+			in:   in{newValue: 0.5, opts: []percent.Option{percent.WithEpsilon(0.01)}},
+			want: want{value: 5000, err: nil},
+		},
+		{
+			name: "last option wins",
+			in: in{
+				newValue: 0.5,
+				opts:     []percent.Option{percent.WithEpsilon(0.01), percent.WithZeroBaseline(percent.ZeroBaselineError)},
+			},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.Change(0, tt.in.newValue, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Change() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value, approx()) {
+				t.Errorf("Change(0, %v) = %v, want %v", tt.in.newValue, got, tt.want.value)
+			}
+		})
+	}
+}
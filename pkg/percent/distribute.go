@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// DistributionMethod selects how Distribute reconciles rounded shares with their total.
+type DistributionMethod int
+
+const (
+	// LargestRemainder (Hamilton's method) rounds every share down and hands the missing
+	// units to the shares with the largest remainders. Equal remainders are resolved in favor
+	// of the earlier part.
+	LargestRemainder DistributionMethod = iota
+	// Cascade rounds the running total of the shares and derives each share from the
+	// difference of consecutive rounded totals, so rounding error is carried forward to the
+	// next part. Halves of a unit are rounded up.
+	Cascade
+)
+
+// Distribute returns the percentage share of each part in the sum of parts, rounded to
+// decimals places so that the shares add up to exactly 100 at that precision.
+//
+// Distribute returns an *Error wrapping ErrNotFinite if a part is NaN or infinite, one wrapping
+// ErrOutOfRange if a part is negative, decimals is not within [0, 15] or method is not one of
+// the DistributionMethod constants, and one wrapping ErrDivideByZero if the parts sum to zero.
+func Distribute[T constraints.Integer | constraints.Float](
+	parts []T, decimals int, method DistributionMethod,
+) ([]float64, error) {
+	d := float64(decimals)
+	if err := checkRange(
+		"Distribute", d, resource.DecimalsMin, resource.DecimalsMax, d,
+	); err != nil {
+		return nil, err
+	}
+
+	sum := new(big.Rat)
+	quotas := make([]*big.Rat, len(parts))
+
+	for i, part := range parts {
+		p := float64(part)
+		if err := checkFinite("Distribute", p); err != nil {
+			return nil, err
+		}
+
+		if p < 0 {
+			return nil, &Error{
+				Op: "Distribute", Inputs: []float64{p}, Min: 0, Max: math.Inf(1),
+				Err: ErrOutOfRange,
+			}
+		}
+
+		quotas[i] = new(big.Rat).SetFloat64(p)
+		sum.Add(sum, quotas[i])
+	}
+
+	if sum.Sign() == 0 {
+		return nil, newError("Distribute", ErrDivideByZero)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	units := new(big.Int).Mul(big.NewInt(int64(resource.PercentMax)), scale)
+
+	factor := new(big.Rat).SetFrac(units, big.NewInt(1))
+	factor.Quo(factor, sum)
+
+	for _, q := range quotas {
+		q.Mul(q, factor)
+	}
+
+	var shares []*big.Int
+
+	switch method {
+	case LargestRemainder:
+		shares = largestRemainder(quotas, units)
+	case Cascade:
+		shares = cascade(quotas)
+	default:
+		return nil, &Error{
+			Op: "Distribute", Inputs: []float64{float64(method)}, Min: float64(LargestRemainder),
+			Max: float64(Cascade), Err: ErrOutOfRange,
+		}
+	}
+
+	pow := math.Pow10(decimals)
+	result := make([]float64, len(shares))
+
+	for i, s := range shares {
+		f, _ := new(big.Float).SetInt(s).Float64()
+		result[i] = f / pow
+	}
+
+	return result, nil
+}
+
+// largestRemainder rounds quotas down and adds one unit to those with the largest remainders
+// until they sum to units.
+func largestRemainder(quotas []*big.Rat, units *big.Int) []*big.Int {
+	shares := make([]*big.Int, len(quotas))
+	remainders := make([]*big.Rat, len(quotas))
+	missing := new(big.Int).Set(units)
+
+	for i, q := range quotas {
+		shares[i] = new(big.Int).Quo(q.Num(), q.Denom())
+		remainders[i] = new(big.Rat).Sub(q, new(big.Rat).SetInt(shares[i]))
+		missing.Sub(missing, shares[i])
+	}
+
+	order := make([]int, len(quotas))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return remainders[b].Cmp(remainders[a])
+	})
+
+	one := big.NewInt(1)
+	for _, i := range order[:missing.Int64()] {
+		shares[i].Add(shares[i], one)
+	}
+
+	return shares
+}
+
+// cascade rounds the running total of quotas and returns the differences of consecutive
+// rounded totals.
+func cascade(quotas []*big.Rat) []*big.Int {
+	shares := make([]*big.Int, len(quotas))
+	total := new(big.Rat)
+	prev := new(big.Int)
+
+	for i, q := range quotas {
+		total.Add(total, q)
+		rounded := roundRat(total, HalfAwayFromZero)
+		shares[i] = new(big.Int).Sub(rounded, prev)
+		prev = rounded
+	}
+
+	return shares
+}
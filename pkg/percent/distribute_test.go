@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestDistribute(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		parts    []float64
+		decimals int
+		method   percent.DistributionMethod
+	}
+
+	type want struct {
+		value []float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "largest remainder thirds",
+			in:   in{parts: []float64{1, 1, 1}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: []float64{34, 33, 33}, err: nil},
+		},
+		{
+			name: "cascade thirds",
+			in:   in{parts: []float64{1, 1, 1}, decimals: 0, method: percent.Cascade},
+			want: want{value: []float64{33, 34, 33}, err: nil},
+		},
+		{
+			name: "largest remainder with decimals",
+			in:   in{parts: []float64{1, 1, 1}, decimals: 2, method: percent.LargestRemainder},
+			want: want{value: []float64{33.34, 33.33, 33.33}, err: nil},
+		},
+		{
+			name: "largest remainder picks largest remainders",
+			in:   in{parts: []float64{10, 25, 65.5}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: []float64{10, 25, 65}, err: nil},
+		},
+		{
+			name: "deterministic ties favor earlier parts",
+			in:   in{parts: []float64{1, 1, 1, 1, 1, 1}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: []float64{17, 17, 17, 17, 16, 16}, err: nil},
+		},
+		{
+			name: "exact shares",
+			in:   in{parts: []float64{2, 3, 5}, decimals: 1, method: percent.Cascade},
+			want: want{value: []float64{20, 30, 50}, err: nil},
+		},
+		{
+			name: "zero part",
+			in:   in{parts: []float64{0, 4}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: []float64{0, 100}, err: nil},
+		},
+		{
+			name: "negative part",
+			in:   in{parts: []float64{1, -1}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: nil, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "infinite part",
+			in:   in{parts: []float64{1, math.Inf(1)}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: nil, err: percent.ErrNotFinite},
+		},
+		{
+			name: "zero sum",
+			in:   in{parts: []float64{0, 0}, decimals: 0, method: percent.LargestRemainder},
+			want: want{value: nil, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "empty parts",
+			in:   in{parts: nil, decimals: 0, method: percent.Cascade},
+			want: want{value: nil, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "too many decimals",
+			in:   in{parts: []float64{1}, decimals: 16, method: percent.LargestRemainder},
+			want: want{value: nil, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "unknown method",
+			in:   in{parts: []float64{1, 1, 1}, decimals: 0, method: percent.DistributionMethod(7)},
+			want: want{value: nil, err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.Distribute(tt.in.parts, tt.in.decimals, tt.in.method)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Distribute() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("Distribute(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func FuzzDistribute(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		a, b, c  uint32
+		decimals uint8
+	}{
+		{1, 1, 1, 0},       // equal parts
+		{1, 2, 3, 2},       // uneven parts
+		{0, 0, 7, 1},       // single non-zero part
+		{1e9, 1, 1, 3},     // dominant part
+		{13, 17, 19, 15},   // maximum precision
+		{999, 333, 111, 4}, // repeating decimals
+	}
+	for _, tc := range testcases {
+		f.Add(tc.a, tc.b, tc.c, tc.decimals) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, a, b, c uint32, decimals uint8) {
+		// Arrange
+		parts := []uint32{a, b, c}
+		d := int(decimals % 16)
+
+		for _, method := range []percent.DistributionMethod{percent.LargestRemainder, percent.Cascade} {
+			// Act
+			got, err := percent.Distribute(parts, d, method)
+
+			// Assert
+			// Property 1: Function should never panic
+			// Property 2: If all parts are zero, should return error
+			// Property 3: If no error, shares sum to exactly 100 at the requested precision
+
+			if a == 0 && b == 0 && c == 0 {
+				if !errors.Is(err, percent.ErrDivideByZero) {
+					t.Errorf("Distribute(%v) error = %v, want ErrDivideByZero", parts, err)
+				}
+
+				continue
+			}
+			if err != nil {
+				t.Fatalf("Distribute(%v, %d) returned unexpected error: %v", parts, d, err)
+			}
+			if d > 12 {
+				// Scaled units exceed float64 integer precision.
+				continue
+			}
+			pow := math.Pow10(d)
+			var sum float64
+			for _, share := range got {
+				sum += math.Round(share * pow)
+			}
+			if sum != 100*pow {
+				t.Errorf("Distribute(%v, %d) = %v, sum %v units, want %v", parts, d, got, sum, 100*pow)
+			}
+		}
+	})
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// Sentinel errors wrapped by every *Error returned from this package. Test for them with
+// errors.Is.
+var (
+	// ErrOutOfRange reports an input outside of its valid range.
+	ErrOutOfRange = resource.ErrOutOfRange
+	// ErrDivideByZero reports a division by a zero total or base value.
+	ErrDivideByZero = resource.ErrDivideByZero
+	// ErrPartGreaterThanTotal reports a part that exceeds its total.
+	ErrPartGreaterThanTotal = resource.ErrPartGreaterThanTotal
+	// ErrSyntax reports input text that cannot be parsed.
+	ErrSyntax = resource.ErrSyntax
+	// ErrSumMismatch reports percentages that do not add up to 100.
+	ErrSumMismatch = resource.ErrSumMismatch
+	// ErrNotFinite reports a NaN or infinite input or result.
+	ErrNotFinite = resource.ErrNotFinite
+	// ErrOverflow reports an integer result that does not fit in its type.
+	ErrOverflow = resource.ErrOverflow
+	// ErrLengthMismatch reports slices of a slice operation whose lengths differ.
+	ErrLengthMismatch = resource.ErrLengthMismatch
+)
+
+// Error describes a failed operation. It wraps one of the sentinel errors, so both errors.Is
+// and errors.As can be used to inspect it.
+type Error struct {
+	// Op is the name of the operation that failed, e.g. "Percent".
+	Op string
+	// Inputs holds the arguments of the operation in call order.
+	Inputs []float64
+	// Min and Max bound the valid range of the offending input. Both are zero when the
+	// failure is not a range violation.
+	Min float64
+	Max float64
+	// Err is the underlying sentinel error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	args := make([]string, len(e.Inputs))
+	for i, v := range e.Inputs {
+		args[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	if e.Min == 0 && e.Max == 0 {
+		return fmt.Sprintf(resource.OperationErrorFormat, e.Err, e.Op, strings.Join(args, ", "))
+	}
+
+	return fmt.Sprintf(
+		resource.OperationRangeErrorFormat, e.Err, e.Op, strings.Join(args, ", "), e.Min, e.Max,
+	)
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError returns an *Error for op that wraps err. Inputs is copied, so that it does not
+// escape to the heap on the success path of the callers.
+func newError(op string, err error, inputs ...float64) *Error {
+	return &Error{Op: op, Inputs: slices.Clone(inputs), Err: err}
+}
+
+// checkRange returns an *Error wrapping ErrOutOfRange if x is not within [lo, hi].
+func checkRange(op string, x, lo, hi float64, inputs ...float64) error {
+	if x < lo || x > hi {
+		return &Error{Op: op, Inputs: slices.Clone(inputs), Min: lo, Max: hi, Err: ErrOutOfRange}
+	}
+
+	return nil
+}
+
+// checkFinite returns an *Error wrapping ErrNotFinite if one of inputs is NaN or infinite.
+func checkFinite(op string, inputs ...float64) error {
+	for _, x := range inputs {
+		if !finite(x) {
+			return newError(op, ErrNotFinite, inputs...)
+		}
+	}
+
+	return nil
+}
+
+// finite reports whether x is neither NaN nor infinite, in which case x-x is zero.
+func finite(x float64) bool {
+	return x-x == 0
+}
+
+// checkPart returns an *Error wrapping ErrPartGreaterThanTotal if part exceeds total.
+func checkPart(op string, part, total float64, inputs ...float64) error {
+	if part > total {
+		return &Error{
+			Op: op, Inputs: slices.Clone(inputs), Min: math.Inf(-1), Max: total,
+			Err: ErrPartGreaterThanTotal,
+		}
+	}
+
+	return nil
+}
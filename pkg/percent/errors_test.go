@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call func() (float64, error)
+	}
+
+	type want struct {
+		err *percent.Error
+		msg string
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "percent out of range",
+			in: in{
+				call: func() (float64, error) { return percent.Percent(150, 100) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "Percent", Inputs: []float64{150, 100}, Min: 0, Max: 100,
+					Err: percent.ErrOutOfRange,
+				},
+				msg: "pkg percent: out of the range: Percent(150, 100) not in [0, 100]",
+			},
+		},
+		{
+			name: "of divide by zero",
+			in: in{
+				call: func() (float64, error) { return percent.Of(5, 0) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "Of", Inputs: []float64{5, 0}, Err: percent.ErrDivideByZero,
+				},
+				msg: "pkg percent: division by zero: Of(5, 0)",
+			},
+		},
+		{
+			name: "of part greater than total",
+			in: in{
+				call: func() (float64, error) { return percent.Of(150, 100) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "Of", Inputs: []float64{150, 100}, Min: math.Inf(-1), Max: 100,
+					Err: percent.ErrPartGreaterThanTotal,
+				},
+				msg: "pkg percent: part cannot be greater than total: Of(150, 100) not in [-Inf, 100]",
+			},
+		},
+		{
+			name: "change divide by zero",
+			in: in{
+				call: func() (float64, error) { return percent.Change(0, 10) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "Change", Inputs: []float64{0, 10}, Err: percent.ErrDivideByZero,
+				},
+				msg: "pkg percent: division by zero: Change(0, 10)",
+			},
+		},
+		{
+			name: "remain out of range",
+			in: in{
+				call: func() (float64, error) { return percent.Remain(-10, 100) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "Remain", Inputs: []float64{-10, 100}, Min: 0, Max: 100,
+					Err: percent.ErrOutOfRange,
+				},
+				msg: "pkg percent: out of the range: Remain(-10, 100) not in [0, 100]",
+			},
+		},
+		{
+			name: "from ratio out of range",
+			in: in{
+				call: func() (float64, error) { return percent.FromRatio(1.5) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "FromRatio", Inputs: []float64{1.5}, Min: 0, Max: 1,
+					Err: percent.ErrOutOfRange,
+				},
+				msg: "pkg percent: out of the range: FromRatio(1.5) not in [0, 1]",
+			},
+		},
+		{
+			name: "to ratio out of range",
+			in: in{
+				call: func() (float64, error) { return percent.ToRatio(101) },
+			},
+			want: want{
+				err: &percent.Error{
+					Op: "ToRatio", Inputs: []float64{101}, Min: 0, Max: 100,
+					Err: percent.ErrOutOfRange,
+				},
+				msg: "pkg percent: out of the range: ToRatio(101) not in [0, 100]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			_, err := tt.in.call()
+
+			// Assert
+			var got *percent.Error
+			if !errors.As(err, &got) {
+				t.Fatalf("error = %v, want *percent.Error", err)
+			}
+			if !errors.Is(err, tt.want.err.Err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err.Err)
+			}
+			if !cmp.Equal(*got, *tt.want.err, cmp.Comparer(errors.Is)) {
+				t.Errorf("error = %#v, want %#v", got, tt.want.err)
+			}
+			if got.Error() != tt.want.msg {
+				t.Errorf("Error() = %q, want %q", got.Error(), tt.want.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package exact provides the percentage operations of package percent using exact rational
+// arithmetic backed by math/big.
+//
+// Results never pick up binary floating-point error, which makes the package suitable for
+// money amounts and other decimal quantities. Errors have the same semantics as in package
+// percent: every failure of an operation is a *percent.Error wrapping one of the percent
+// sentinel errors, and a failure of Parse is a *percent.SyntaxError.
+//
+// As with math/big, the arguments must not be nil; a nil *big.Int or *big.Rat panics.
+package exact
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// Number is the set of arbitrary-precision types accepted by the operations.
+type Number interface {
+	*big.Int | *big.Rat
+}
+
+// Percent returns the percentage of value.
+func Percent[T Number](pct, value T) (*big.Rat, error) {
+	p, v := rat(pct), rat(value)
+	if err := checkRange("Percent", p, percentMax(), p, v); err != nil {
+		return nil, err
+	}
+
+	r := new(big.Rat).Mul(v, p)
+
+	return r.Quo(r, percentMax()), nil
+}
+
+// Of calculates the percentage of the part relative to the total.
+func Of[T Number](part, total T) (*big.Rat, error) {
+	p, t := rat(part), rat(total)
+	if t.Sign() == 0 {
+		return nil, newError("Of", percent.ErrDivideByZero, p, t)
+	}
+
+	if p.Cmp(t) > 0 {
+		f, _ := t.Float64()
+
+		return nil, &percent.Error{
+			Op:     "Of",
+			Inputs: floats(p, t),
+			Min:    math.Inf(-1),
+			Max:    f,
+			Err:    percent.ErrPartGreaterThanTotal,
+		}
+	}
+
+	r := new(big.Rat).Quo(p, t)
+
+	return r.Mul(r, percentMax()), nil
+}
+
+// Change calculates the percentage change between two values.
+func Change[T Number](oldValue, newValue T) (*big.Rat, error) {
+	o, n := rat(oldValue), rat(newValue)
+	if o.Sign() == 0 {
+		return nil, newError("Change", percent.ErrDivideByZero, o, n)
+	}
+
+	r := new(big.Rat).Sub(n, o)
+	r.Quo(r, new(big.Rat).Abs(o))
+
+	return r.Mul(r, percentMax()), nil
+}
+
+// Remain returns the percentage of value that remains after subtracting the percentage.
+func Remain[T Number](pct, value T) (*big.Rat, error) {
+	p, v := rat(pct), rat(value)
+	if err := checkRange("Remain", p, percentMax(), p, v); err != nil {
+		return nil, err
+	}
+
+	r := new(big.Rat).Sub(percentMax(), p)
+	r.Mul(v, r)
+
+	return r.Quo(r, percentMax()), nil
+}
+
+// FromRatio returns the percent of ratio.
+func FromRatio[T Number](ratio T) (*big.Rat, error) {
+	r := rat(ratio)
+	if err := checkRange("FromRatio", r, big.NewRat(1, 1), r); err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Mul(r, percentMax()), nil
+}
+
+// ToRatio returns the ratio of percent.
+func ToRatio[T Number](pct T) (*big.Rat, error) {
+	p := rat(pct)
+	if err := checkRange("ToRatio", p, percentMax(), p); err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Quo(p, percentMax()), nil
+}
+
+// Parse returns the rational number represented by s. It accepts decimal ("12.5"), exponent
+// ("1.25e1") and fraction ("25/2") notation. It returns a *percent.SyntaxError if s is not a
+// valid number.
+func Parse(s string) (*big.Rat, error) {
+	if s == "" {
+		return nil, &percent.SyntaxError{Input: s, Offset: 0, Msg: resource.EmptyInputMessage}
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, &percent.SyntaxError{Input: s, Offset: 0, Msg: resource.InvalidNumberMessage}
+	}
+
+	return r, nil
+}
+
+// Decimal returns the exact decimal representation of r. It reports false if r has no finite
+// decimal representation, such as 1/3.
+func Decimal(r *big.Rat) (string, bool) {
+	prec, exact := r.FloatPrec()
+	if !exact {
+		return "", false
+	}
+
+	return r.FloatString(prec), true
+}
+
+// Format returns r as a decimal string with prec digits after the decimal point. The last
+// digit is rounded to nearest, with halves rounded away from zero.
+func Format(r *big.Rat, prec int) string {
+	return r.FloatString(prec)
+}
+
+// rat returns x as a *big.Rat without aliasing it.
+func rat[T Number](x T) *big.Rat {
+	switch v := any(x).(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(v)
+	case *big.Rat:
+		return new(big.Rat).Set(v)
+	default:
+		panic("unreachable")
+	}
+}
+
+// percentMax returns 100 as a *big.Rat.
+func percentMax() *big.Rat {
+	return big.NewRat(int64(resource.PercentMax), 1)
+}
+
+// floats returns xs as approximate float64 values for error reporting.
+func floats(xs ...*big.Rat) []float64 {
+	fs := make([]float64, len(xs))
+	for i, x := range xs {
+		fs[i], _ = x.Float64()
+	}
+
+	return fs
+}
+
+// newError returns a *percent.Error for op that wraps err.
+func newError(op string, err error, inputs ...*big.Rat) *percent.Error {
+	return &percent.Error{Op: op, Inputs: floats(inputs...), Err: err}
+}
+
+// checkRange returns a *percent.Error wrapping percent.ErrOutOfRange if x is not within
+// [0, hi].
+func checkRange(op string, x, hi *big.Rat, inputs ...*big.Rat) error {
+	if x.Sign() < 0 || x.Cmp(hi) > 0 {
+		f, _ := hi.Float64()
+
+		return &percent.Error{
+			Op:     op,
+			Inputs: floats(inputs...),
+			Min:    0,
+			Max:    f,
+			Err:    percent.ErrOutOfRange,
+		}
+	}
+
+	return nil
+}
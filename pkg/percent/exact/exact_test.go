@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exact_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/sentenz/percent/pkg/percent"
+	"github.com/sentenz/percent/pkg/percent/exact"
+)
+
+func mustRat(t *testing.T, s string) *big.Rat {
+	t.Helper()
+
+	r, err := exact.Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", s, err)
+	}
+
+	return r
+}
+
+func TestOperations(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		op   string
+		args []string
+	}
+
+	type want struct {
+		value string
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "percent without binary error",
+			in:   in{op: "Percent", args: []string{"7", "0.1"}},
+			want: want{value: "0.007", err: nil},
+		},
+		{
+			name: "percent of money amount",
+			in:   in{op: "Percent", args: []string{"19", "1234.56"}},
+			want: want{value: "234.5664", err: nil},
+		},
+		{
+			name: "percent out of range",
+			in:   in{op: "Percent", args: []string{"100.01", "1"}},
+			want: want{value: "", err: percent.ErrOutOfRange},
+		},
+		{
+			name: "of repeating decimal",
+			in:   in{op: "Of", args: []string{"1", "3"}},
+			want: want{value: "100/3", err: nil},
+		},
+		{
+			name: "of divide by zero",
+			in:   in{op: "Of", args: []string{"1", "0"}},
+			want: want{value: "", err: percent.ErrDivideByZero},
+		},
+		{
+			name: "of part greater than total",
+			in:   in{op: "Of", args: []string{"3", "2"}},
+			want: want{value: "", err: percent.ErrPartGreaterThanTotal},
+		},
+		{
+			name: "change increase",
+			in:   in{op: "Change", args: []string{"0.3", "0.6"}},
+			want: want{value: "100", err: nil},
+		},
+		{
+			name: "change negative base",
+			in:   in{op: "Change", args: []string{"-50", "-200"}},
+			want: want{value: "-300", err: nil},
+		},
+		{
+			name: "change divide by zero",
+			in:   in{op: "Change", args: []string{"0", "1"}},
+			want: want{value: "", err: percent.ErrDivideByZero},
+		},
+		{
+			name: "remain",
+			in:   in{op: "Remain", args: []string{"0.1", "0.3"}},
+			want: want{value: "0.2997", err: nil},
+		},
+		{
+			name: "remain out of range",
+			in:   in{op: "Remain", args: []string{"-1", "10"}},
+			want: want{value: "", err: percent.ErrOutOfRange},
+		},
+		{
+			name: "from ratio",
+			in:   in{op: "FromRatio", args: []string{"0.0007"}},
+			want: want{value: "0.07", err: nil},
+		},
+		{
+			name: "from ratio out of range",
+			in:   in{op: "FromRatio", args: []string{"1.5"}},
+			want: want{value: "", err: percent.ErrOutOfRange},
+		},
+		{
+			name: "to ratio",
+			in:   in{op: "ToRatio", args: []string{"7"}},
+			want: want{value: "0.07", err: nil},
+		},
+		{
+			name: "to ratio out of range",
+			in:   in{op: "ToRatio", args: []string{"101"}},
+			want: want{value: "", err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			args := make([]*big.Rat, len(tt.in.args))
+			for i, s := range tt.in.args {
+				args[i] = mustRat(t, s)
+			}
+
+			// Act
+			var (
+				got *big.Rat
+				err error
+			)
+			switch tt.in.op {
+			case "Percent":
+				got, err = exact.Percent(args[0], args[1])
+			case "Of":
+				got, err = exact.Of(args[0], args[1])
+			case "Change":
+				got, err = exact.Change(args[0], args[1])
+			case "Remain":
+				got, err = exact.Remain(args[0], args[1])
+			case "FromRatio":
+				got, err = exact.FromRatio(args[0])
+			case "ToRatio":
+				got, err = exact.ToRatio(args[0])
+			}
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("%s() error = %v, want err %v", tt.in.op, err, tt.want.err)
+			}
+			if tt.want.err != nil {
+				var e *percent.Error
+				if !errors.As(err, &e) || e.Op != tt.in.op {
+					t.Errorf("%s() error = %#v, want *percent.Error for %s", tt.in.op, err, tt.in.op)
+				}
+				if got != nil {
+					t.Errorf("%s(%v) = %v, want nil on error", tt.in.op, tt.in.args, got)
+				}
+
+				return
+			}
+			if want := mustRat(t, tt.want.value); got.Cmp(want) != 0 {
+				t.Errorf("%s(%v) = %v, want %v", tt.in.op, tt.in.args, got, want)
+			}
+		})
+	}
+}
+
+func TestIntegerInputs(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	value, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	// Act
+	got, err := exact.Percent(big.NewInt(10), value)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Percent() error = %v", err)
+	}
+	if want := mustRat(t, "12345678901234567890123456789"); got.Cmp(want) != 0 {
+		t.Errorf("Percent(10, %v) = %v, want %v", value, got, want)
+	}
+}
+
+func TestInputsNotMutated(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	p, v := big.NewRat(25, 1), big.NewRat(200, 1)
+
+	// Act
+	_, _ = exact.Percent(p, v)
+	_, _ = exact.Remain(p, v)
+
+	// Assert
+	if p.Cmp(big.NewRat(25, 1)) != 0 || v.Cmp(big.NewRat(200, 1)) != 0 {
+		t.Errorf("inputs mutated to %v, %v", p, v)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{name: "decimal", in: "12.5", want: nil},
+		{name: "exponent", in: "1.25e1", want: nil},
+		{name: "fraction", in: "25/2", want: nil},
+		{name: "empty", in: "", want: percent.ErrSyntax},
+		{name: "garbage", in: "12,5", want: percent.ErrSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := exact.Parse(tt.in)
+
+			// Assert
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Parse(%q) error = %v, want err %v", tt.in, err, tt.want)
+			}
+			var se *percent.SyntaxError
+			if err != nil && (!errors.As(err, &se) || se.Input != tt.in) {
+				t.Errorf("Parse(%q) error = %#v, want a *percent.SyntaxError", tt.in, err)
+			}
+			if err == nil && got.Cmp(big.NewRat(25, 2)) != 0 {
+				t.Errorf("Parse(%q) = %v, want 25/2", tt.in, got)
+			}
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		in     *big.Rat
+		want   string
+		wantOK bool
+	}{
+		{name: "integer", in: big.NewRat(25, 1), want: "25", wantOK: true},
+		{name: "terminating", in: big.NewRat(1, 8), want: "0.125", wantOK: true},
+		{name: "negative", in: big.NewRat(-7, 1000), want: "-0.007", wantOK: true},
+		{name: "repeating", in: big.NewRat(1, 3), want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, ok := exact.Decimal(tt.in)
+
+			// Assert
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Decimal(%v) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	in := big.NewRat(100, 3)
+
+	// Act
+	got := exact.Format(in, 2)
+
+	// Assert
+	if got != "33.33" {
+		t.Errorf("Format(%v, 2) = %q, want %q", in, got, "33.33")
+	}
+}
+
+// TestCrossCheck verifies that the exact operations agree with the float operations of package
+// percent, both in value and in error semantics.
+func TestCrossCheck(t *testing.T) {
+	t.Parallel()
+
+	values := []float64{0, 1, -1, 0.1, 7, 25, 33.3, 50, 99.99, 100, 150, -200, 1234.56}
+
+	check := func(t *testing.T, name string, in []float64, got *big.Rat, err error, want float64, wantErr error) {
+		t.Helper()
+
+		if (err == nil) != (wantErr == nil) || (err != nil && !errors.Is(err, errors.Unwrap(wantErr))) {
+			t.Errorf("%s(%v) error = %v, want err %v", name, in, err, wantErr)
+
+			return
+		}
+		if err != nil {
+			return
+		}
+		f, _ := got.Float64()
+		if math.Abs(f-want) > 1e-9*math.Max(1, math.Abs(want)) {
+			t.Errorf("%s(%v) = %v, want %v", name, in, f, want)
+		}
+	}
+
+	for _, a := range values {
+		ra := new(big.Rat).SetFloat64(a)
+
+		got, err := exact.FromRatio(ra)
+		want, wantErr := percent.FromRatio(a)
+		check(t, "FromRatio", []float64{a}, got, err, want, wantErr)
+
+		got, err = exact.ToRatio(ra)
+		want, wantErr = percent.ToRatio(a)
+		check(t, "ToRatio", []float64{a}, got, err, want, wantErr)
+
+		for _, b := range values {
+			rb := new(big.Rat).SetFloat64(b)
+			in := []float64{a, b}
+
+			got, err = exact.Percent(ra, rb)
+			want, wantErr = percent.Percent(a, b)
+			check(t, "Percent", in, got, err, want, wantErr)
+
+			got, err = exact.Of(ra, rb)
+			want, wantErr = percent.Of(a, b)
+			check(t, "Of", in, got, err, want, wantErr)
+
+			got, err = exact.Change(ra, rb)
+			want, wantErr = percent.Change(a, b)
+			check(t, "Change", in, got, err, want, wantErr)
+
+			got, err = exact.Remain(ra, rb)
+			want, wantErr = percent.Remain(a, b)
+			check(t, "Remain", in, got, err, want, wantErr)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package expr
+
+import (
+	"strconv"
+)
+
+// Node is a node of the syntax tree of an expression. String returns the node as an
+// expression that parses back to the same tree, with every operation in parentheses.
+type Node interface {
+	// Pos returns the byte offset of the first token of the node in the input.
+	Pos() int
+	// String returns the node in canonical form.
+	String() string
+
+	node()
+}
+
+// Func is an operation of package percent that a Call node applies.
+type Func int
+
+const (
+	// FuncPercent is "p% of value", Percent(p, value).
+	FuncPercent Func = iota
+	// FuncOf is "what percent is part of total", Of(part, total).
+	FuncOf
+	// FuncChange is "change from old to new", Change(old, new).
+	FuncChange
+	// FuncRemain is "value minus p%" or "value decreased by p%", Remain(p, value).
+	FuncRemain
+	// FuncIncrease is "value plus p%" or "value increased by p%", value * (1 + p/100).
+	FuncIncrease
+)
+
+// String returns the name of the function of package percent that f applies, e.g. "Of", or
+// "Increase" for FuncIncrease.
+func (f Func) String() string {
+	switch f {
+	case FuncPercent:
+		return "Percent"
+	case FuncOf:
+		return "Of"
+	case FuncChange:
+		return "Change"
+	case FuncRemain:
+		return "Remain"
+	case FuncIncrease:
+		return "Increase"
+	}
+
+	return "Func(" + strconv.Itoa(int(f)) + ")"
+}
+
+// Op is an arithmetic operator of a Binary node.
+type Op int
+
+const (
+	// Add is "+" or "plus".
+	Add Op = iota
+	// Sub is "-" or "minus".
+	Sub
+	// Mul is "*" or "times".
+	Mul
+	// Div is "/" or "divided by".
+	Div
+)
+
+// String returns the symbol of o, e.g. "+".
+func (o Op) String() string {
+	switch o {
+	case Add:
+		return "+"
+	case Sub:
+		return "-"
+	case Mul:
+		return "*"
+	case Div:
+		return "/"
+	}
+
+	return "Op(" + strconv.Itoa(int(o)) + ")"
+}
+
+// Literal is a number.
+type Literal struct {
+	// Value is the value of the number.
+	Value float64
+	// Text is the number as written in the input.
+	Text string
+	// Offset is the byte offset of the number in the input.
+	Offset int
+}
+
+// Name is a variable, or a reference to a previous result such as "$1".
+type Name struct {
+	// Name is the name as written in the input.
+	Name string
+	// Offset is the byte offset of the name in the input.
+	Offset int
+}
+
+// Percentage is a value followed by a percent sign, whose value is its number of percent. As
+// the right operand of plus or minus, it is a relative increase or decrease instead.
+type Percentage struct {
+	// X is the number of percent.
+	X Node
+}
+
+// Neg is a negated value.
+type Neg struct {
+	// X is the negated value.
+	X Node
+	// Offset is the byte offset of the minus sign in the input.
+	Offset int
+}
+
+// Binary is an arithmetic operation.
+type Binary struct {
+	// Op is the operator.
+	Op Op
+	// X and Y are the operands.
+	X, Y Node
+}
+
+// Call is an operation of package percent.
+type Call struct {
+	// Func is the operation.
+	Func Func
+	// Args are the arguments of the operation in the order of the function of package
+	// percent, e.g. part and total for FuncOf.
+	Args [2]Node
+	// Offset is the byte offset of the first token of the operation in the input.
+	Offset int
+}
+
+// Assignment binds a variable to the value of an expression.
+type Assignment struct {
+	// Name is the variable.
+	Name *Name
+	// X is the assigned expression.
+	X Node
+}
+
+func (*Literal) node()    {}
+func (*Name) node()       {}
+func (*Percentage) node() {}
+func (*Neg) node()        {}
+func (*Binary) node()     {}
+func (*Call) node()       {}
+func (*Assignment) node() {}
+
+// Pos implements Node.
+func (n *Literal) Pos() int { return n.Offset }
+
+// Pos implements Node.
+func (n *Name) Pos() int { return n.Offset }
+
+// Pos implements Node.
+func (n *Percentage) Pos() int { return n.X.Pos() }
+
+// Pos implements Node.
+func (n *Neg) Pos() int { return n.Offset }
+
+// Pos implements Node.
+func (n *Binary) Pos() int { return n.X.Pos() }
+
+// Pos implements Node.
+func (n *Call) Pos() int { return n.Offset }
+
+// Pos implements Node.
+func (n *Assignment) Pos() int { return n.Name.Offset }
+
+// String implements Node.
+func (n *Literal) String() string { return n.Text }
+
+// String implements Node.
+func (n *Name) String() string { return n.Name }
+
+// String implements Node, e.g. "15%".
+func (n *Percentage) String() string { return operand(n.X) + "%" }
+
+// String implements Node, e.g. "-x".
+func (n *Neg) String() string { return "-" + operand(n.X) }
+
+// String implements Node, e.g. "(a + b)".
+func (n *Binary) String() string {
+	return "(" + n.X.String() + " " + n.Op.String() + " " + n.Y.String() + ")"
+}
+
+// String implements Node, e.g. "(15% of 240)".
+func (n *Call) String() string {
+	a, b := n.Args[0].String(), n.Args[1].String()
+
+	switch n.Func {
+	case FuncPercent:
+		return "(" + operand(n.Args[0]) + "% of " + b + ")"
+	case FuncOf:
+		return "(what percent is " + a + " of " + b + ")"
+	case FuncChange:
+		return "(change from " + a + " to " + b + ")"
+	case FuncRemain:
+		return "(" + b + " minus " + operand(n.Args[0]) + "%)"
+	case FuncIncrease:
+		return "(" + b + " plus " + operand(n.Args[0]) + "%)"
+	}
+
+	return n.Func.String() + "(" + a + ", " + b + ")"
+}
+
+// String implements Node, e.g. "a = 240".
+func (n *Assignment) String() string { return n.Name.String() + " = " + n.X.String() }
+
+// operand returns n as the operand of a percent sign or a minus sign, in parentheses unless it
+// is a single token or already in parentheses.
+func operand(n Node) string {
+	switch n := n.(type) {
+	case *Neg, *Percentage:
+		return "(" + n.String() + ")"
+	}
+
+	return n.String()
+}
@@ -0,0 +1,633 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package expr evaluates percentage expressions written in plain English, such as
+// "15% of 240", "what percent is 30 of 120", "80 increased by 12.5%" or
+// "200 minus 20% minus 10%", using the operations of package percent.
+//
+// The grammar of an expression, with keywords matched without regard to case, is:
+//
+//	statement = [ name "=" ] expr .
+//	expr      = sum [ "is" "what" percent "of" sum ] .
+//	sum       = product { ( "+" | "plus" | "-" | "minus" ) product
+//	                    | ( "increased" | "decreased" ) "by" product } .
+//	product   = unary { ( "*" | "times" | "/" | "divided" "by" ) unary } .
+//	unary     = "-" unary | postfix .
+//	postfix   = primary [ percent [ "of" unary ] ] .
+//	primary   = number | name | "$" digits | "(" expr ")"
+//	          | "what" percent "is" sum "of" sum
+//	          | [ percent ] "change" "from" sum "to" sum .
+//	percent   = "%" | "percent" | "percentage" | "pct" .
+//
+// The operations map onto package percent: "p% of v" is Percent(p, v), "what percent is a of
+// b" and "a is what percent of b" are Of(a, b), "change from a to b" is Change(a, b), and
+// "v minus p%" or "v decreased by p%" is Remain(p, v). A percentage as the right operand of
+// plus or minus is relative to the left operand, so "200 minus 20% minus 10%" is 144, while
+// "200 minus 20" is 180. A percentage on its own is its number of percent.
+//
+// Parse returns the syntax tree of an expression for inspection, and Eval computes its value.
+// Syntax errors are *percent.SyntaxError values holding the offset of the offending token.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// ErrUndefined reports a name that has no value in the environment of an evaluation.
+var ErrUndefined = resource.ErrUndefined
+
+// Env holds the values of names, such as variables and references to previous results like
+// "$1", for an evaluation.
+type Env map[string]float64
+
+// Evaluate parses and evaluates the statement s.
+func Evaluate(s string, env Env, opts ...percent.Option) (float64, error) {
+	n, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return Eval(n, env, opts...)
+}
+
+// Parse returns the syntax tree of the statement s.
+//
+// Parse returns a *percent.SyntaxError, which wraps percent.ErrSyntax, if s is not a valid
+// statement.
+func Parse(s string) (Node, error) {
+	toks, err := Tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{input: s, toks: toks, pos: 0}
+
+	n, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.Kind != EOF {
+		return nil, p.unexpected(t)
+	}
+
+	return n, nil
+}
+
+// keywords returns the reserved words of the grammar, which cannot be names.
+func keywords() []string {
+	return []string{
+		"by", "change", "decreased", "divided", "from", "increased", "is", "minus", "of", "percent",
+		"percentage", "pct", "plus", "times", "to", "what",
+	}
+}
+
+// parser is a recursive descent parser of the grammar.
+type parser struct {
+	input string
+	toks  []Token
+	pos   int
+}
+
+// peek returns the next token.
+func (p *parser) peek() Token {
+	return p.toks[p.pos]
+}
+
+// next consumes and returns the next token.
+func (p *parser) next() Token {
+	t := p.toks[p.pos]
+	if t.Kind != EOF {
+		p.pos++
+	}
+
+	return t
+}
+
+// symbol consumes the next token if it is of kind k.
+func (p *parser) symbol(k Kind) bool {
+	if p.peek().Kind != k {
+		return false
+	}
+
+	p.next()
+
+	return true
+}
+
+// keyword consumes the next token if it is one of words.
+func (p *parser) keyword(words ...string) bool {
+	if !isKeyword(p.peek(), words...) {
+		return false
+	}
+
+	p.next()
+
+	return true
+}
+
+// isKeyword reports whether t is one of words.
+func isKeyword(t Token, words ...string) bool {
+	return t.Kind == Word && slices.ContainsFunc(words, func(w string) bool { return strings.EqualFold(t.Text, w) })
+}
+
+// percentSign consumes a percent sign or word.
+func (p *parser) percentSign() bool {
+	return p.symbol(Percent) || p.keyword("percent", "percentage", "pct")
+}
+
+// expect consumes the keyword word, or returns an error.
+func (p *parser) expect(word string) error {
+	if !p.keyword(word) {
+		return p.expected(strconv.Quote(word))
+	}
+
+	return nil
+}
+
+// unexpected returns a syntax error at t.
+func (p *parser) unexpected(t Token) error {
+	return syntaxError(p.input, t.Offset, fmt.Sprintf(resource.UnexpectedTokenFormat, t))
+}
+
+// expected returns a syntax error at the next token, which is not what was expected.
+func (p *parser) expected(what string) error {
+	t := p.peek()
+
+	return syntaxError(p.input, t.Offset, fmt.Sprintf(resource.ExpectedTokenFormat, what, t))
+}
+
+// statement parses a statement.
+func (p *parser) statement() (Node, error) {
+	if t := p.peek(); t.Kind == Word && p.toks[p.pos+1].Kind == Assign {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		p.next()
+
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Assignment{Name: name, X: x}, nil
+	}
+
+	return p.expr()
+}
+
+// expr parses an expression.
+func (p *parser) expr() (Node, error) {
+	x, err := p.sum()
+	if err != nil || !p.keyword("is") {
+		return x, err
+	}
+
+	if err := p.expect("what"); err != nil {
+		return nil, err
+	}
+
+	if !p.percentSign() {
+		return nil, p.expected(`"percent"`)
+	}
+
+	if err := p.expect("of"); err != nil {
+		return nil, err
+	}
+
+	y, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Call{Func: FuncOf, Args: [2]Node{x, y}, Offset: x.Pos()}, nil
+}
+
+// sum parses a sum, in which a percentage as the right operand of plus or minus is an
+// increase or a decrease.
+func (p *parser) sum() (Node, error) {
+	x, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var (
+			op       Op
+			relative bool
+		)
+
+		switch {
+		case p.symbol(Plus) || p.keyword("plus"):
+			op = Add
+		case p.symbol(Minus) || p.keyword("minus"):
+			op = Sub
+		case p.keyword("increased"):
+			op, relative = Add, true
+		case p.keyword("decreased"):
+			op, relative = Sub, true
+		default:
+			return x, nil
+		}
+
+		if relative {
+			if err := p.expect("by"); err != nil {
+				return nil, err
+			}
+		}
+
+		y, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+
+		if pct, ok := y.(*Percentage); ok {
+			y, relative = pct.X, true
+		}
+
+		if !relative {
+			x = &Binary{Op: op, X: x, Y: y}
+
+			continue
+		}
+
+		f := FuncIncrease
+		if op == Sub {
+			f = FuncRemain
+		}
+
+		x = &Call{Func: f, Args: [2]Node{y, x}, Offset: x.Pos()}
+	}
+}
+
+// product parses a product.
+func (p *parser) product() (Node, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var op Op
+
+		switch {
+		case p.symbol(Star) || p.keyword("times"):
+			op = Mul
+		case p.symbol(Slash):
+			op = Div
+		case p.keyword("divided"):
+			if err := p.expect("by"); err != nil {
+				return nil, err
+			}
+
+			op = Div
+		default:
+			return x, nil
+		}
+
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		x = &Binary{Op: op, X: x, Y: y}
+	}
+}
+
+// unary parses a negation or a postfix expression.
+func (p *parser) unary() (Node, error) {
+	if t := p.peek(); p.symbol(Minus) {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Neg{X: x, Offset: t.Offset}, nil
+	}
+
+	return p.postfix()
+}
+
+// postfix parses a primary expression that may be followed by a percent sign, and then by
+// "of" and the value the percentage is taken of.
+func (p *parser) postfix() (Node, error) {
+	x, err := p.primary()
+	if err != nil || !p.percentSign() {
+		return x, err
+	}
+
+	if !p.keyword("of") {
+		return &Percentage{X: x}, nil
+	}
+
+	y, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Call{Func: FuncPercent, Args: [2]Node{x, y}, Offset: x.Pos()}, nil
+}
+
+// primary parses a number, a name, an expression in parentheses or a question.
+func (p *parser) primary() (Node, error) {
+	t := p.peek()
+
+	switch {
+	case t.Kind == Number:
+		p.next()
+
+		v, err := strconv.ParseFloat(t.Text, 64)
+		if err != nil {
+			return nil, syntaxError(p.input, t.Offset, resource.ExpectedDigitMessage)
+		}
+
+		return &Literal{Value: v, Text: t.Text, Offset: t.Offset}, nil
+	case t.Kind == Ref:
+		p.next()
+
+		return &Name{Name: t.Text, Offset: t.Offset}, nil
+	case p.symbol(LParen):
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.symbol(RParen) {
+			return nil, p.expected(`")"`)
+		}
+
+		return x, nil
+	case p.keyword("what"):
+		return p.question(t)
+	case isKeyword(t, "change") || isKeyword(t, "percent", "percentage", "pct") && isKeyword(p.toks[p.pos+1], "change"):
+		return p.change(t)
+	}
+
+	return p.name()
+}
+
+// question parses "what percent is a of b" after "what".
+func (p *parser) question(start Token) (Node, error) {
+	if !p.percentSign() {
+		return nil, p.expected(`"percent"`)
+	}
+
+	if err := p.expect("is"); err != nil {
+		return nil, err
+	}
+
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("of"); err != nil {
+		return nil, err
+	}
+
+	y, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Call{Func: FuncOf, Args: [2]Node{x, y}, Offset: start.Offset}, nil
+}
+
+// change parses "change from a to b", which may be preceded by "percent".
+func (p *parser) change(start Token) (Node, error) {
+	p.percentSign()
+	p.next()
+
+	if err := p.expect("from"); err != nil {
+		return nil, err
+	}
+
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("to"); err != nil {
+		return nil, err
+	}
+
+	y, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Call{Func: FuncChange, Args: [2]Node{x, y}, Offset: start.Offset}, nil
+}
+
+// name parses a name that is not a keyword.
+func (p *parser) name() (*Name, error) {
+	t := p.peek()
+	if t.Kind != Word || isKeyword(t, keywords()...) {
+		return nil, p.unexpected(t)
+	}
+
+	p.next()
+
+	return &Name{Name: t.Text, Offset: t.Offset}, nil
+}
+
+// Eval returns the value of the syntax tree n. Names are looked up in env, and an Assignment
+// stores its value in env, which must then not be nil. The options apply to the operations of
+// package percent.
+//
+// Eval returns an error wrapping ErrUndefined if a name has no value, and the *percent.Error
+// of an operation that fails, including a division by zero.
+func Eval(n Node, env Env, opts ...percent.Option) (float64, error) {
+	e := &evaluator{env: env, opts: opts, trace: false, steps: nil}
+
+	return e.eval(n)
+}
+
+// Step is an operation performed by Trace.
+type Step struct {
+	// Node is the *Call or *Binary node of the operation.
+	Node Node
+	// X and Y are the values of the operands, in the order of the Args of a *Call.
+	X, Y float64
+	// Value is the result of the operation.
+	Value float64
+}
+
+// Trace is like Eval, but also returns the operations it performed, in the order they were
+// performed, up to the one that failed.
+func Trace(n Node, env Env, opts ...percent.Option) (float64, []Step, error) {
+	e := &evaluator{env: env, opts: opts, trace: true, steps: nil}
+
+	v, err := e.eval(n)
+
+	return v, e.steps, err
+}
+
+// evaluator evaluates syntax trees.
+type evaluator struct {
+	env   Env
+	opts  []percent.Option
+	trace bool
+	steps []Step
+}
+
+// step records an operation if e traces, and returns its value.
+func (e *evaluator) step(n Node, x, y, v float64) float64 {
+	if e.trace {
+		e.steps = append(e.steps, Step{Node: n, X: x, Y: y, Value: v})
+	}
+
+	return v
+}
+
+// eval returns the value of n.
+func (e *evaluator) eval(n Node) (float64, error) {
+	switch n := n.(type) {
+	case *Literal:
+		return n.Value, nil
+	case *Name:
+		v, ok := e.env[n.Name]
+		if !ok {
+			return 0, fmt.Errorf(resource.UndefinedErrorFormat, ErrUndefined, n.Name, n.Offset)
+		}
+
+		return v, nil
+	case *Percentage:
+		return e.eval(n.X)
+	case *Neg:
+		x, err := e.eval(n.X)
+		if err != nil {
+			return 0, err
+		}
+
+		return -x, nil
+	case *Binary:
+		return e.binary(n)
+	case *Call:
+		return e.call(n)
+	case *Assignment:
+		x, err := e.eval(n.X)
+		if err != nil {
+			return 0, err
+		}
+
+		e.env[n.Name.Name] = x
+
+		return x, nil
+	}
+
+	return 0, fmt.Errorf(resource.UnknownNodeFormat, percent.ErrSyntax, n)
+}
+
+// binary returns the value of an arithmetic operation.
+func (e *evaluator) binary(n *Binary) (float64, error) {
+	x, err := e.eval(n.X)
+	if err != nil {
+		return 0, err
+	}
+
+	y, err := e.eval(n.Y)
+	if err != nil {
+		return 0, err
+	}
+
+	var v float64
+
+	switch n.Op {
+	case Add:
+		v = x + y
+	case Sub:
+		v = x - y
+	case Mul:
+		v = x * y
+	case Div:
+		if y == 0 {
+			return 0, &percent.Error{
+				Op: n.Op.name(), Inputs: []float64{x, y}, Min: 0, Max: 0, Err: percent.ErrDivideByZero,
+			}
+		}
+
+		v = x / y
+	}
+
+	if v-v != 0 {
+		return 0, &percent.Error{
+			Op: n.Op.name(), Inputs: []float64{x, y}, Min: 0, Max: 0, Err: percent.ErrNotFinite,
+		}
+	}
+
+	return e.step(n, x, y, v), nil
+}
+
+// call returns the value of an operation of package percent.
+func (e *evaluator) call(n *Call) (float64, error) {
+	a, err := e.eval(n.Args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := e.eval(n.Args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	var v float64
+
+	switch n.Func {
+	case FuncPercent:
+		v, err = percent.Percent(a, b, e.opts...)
+	case FuncOf:
+		v, err = percent.Of(a, b, e.opts...)
+	case FuncChange:
+		v, err = percent.Change(a, b, e.opts...)
+	case FuncRemain:
+		v, err = percent.Remain(a, b, e.opts...)
+	case FuncIncrease:
+		v, err = increase(a, b, e.opts)
+	default:
+		err = fmt.Errorf(resource.UnknownFunctionFormat, percent.ErrSyntax, n.Func)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return e.step(n, a, b, v), nil
+}
+
+// increase returns value increased by p percent, value * (1 + p/100). Unlike Percent, it does
+// not bound p by the Policy, so "80 increased by 150%" is 200.
+func increase(p, value float64, opts []percent.Option) (float64, error) {
+	v, err := percent.Apply(percent.Percentage(p+100), value, opts...)
+
+	var pe *percent.Error
+	if errors.As(err, &pe) {
+		return 0, &percent.Error{Op: "Increase", Inputs: []float64{p, value}, Min: pe.Min, Max: pe.Max, Err: pe.Err}
+	}
+
+	return v, err
+}
+
+// name returns the name of o in errors, e.g. "Add".
+func (o Op) name() string {
+	switch o {
+	case Add:
+		return "Add"
+	case Sub:
+		return "Sub"
+	case Mul:
+		return "Mul"
+	case Div:
+		return "Div"
+	}
+
+	return o.String()
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package expr_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+	"github.com/sentenz/percent/pkg/percent/expr"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		tree   string
+		offset int
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "percent of", in: in{s: "15% of 240"}, want: want{tree: "(15% of 240)", offset: 0, err: nil}},
+		{
+			name: "percent word",
+			in:   in{s: "15 Percent OF 240"},
+			want: want{tree: "(15% of 240)", offset: 0, err: nil},
+		},
+		{
+			name: "what percent",
+			in:   in{s: "what percent is 30 of 120"},
+			want: want{tree: "(what percent is 30 of 120)", offset: 0, err: nil},
+		},
+		{
+			name: "is what percent",
+			in:   in{s: "30 is what percentage of 120"},
+			want: want{tree: "(what percent is 30 of 120)", offset: 0, err: nil},
+		},
+		{
+			name: "increased by",
+			in:   in{s: "80 increased by 12.5%"},
+			want: want{tree: "(80 plus 12.5%)", offset: 0, err: nil},
+		},
+		{
+			name: "decreased by",
+			in:   in{s: "80 decreased by 12.5"},
+			want: want{tree: "(80 minus 12.5%)", offset: 0, err: nil},
+		},
+		{
+			name: "successive decreases",
+			in:   in{s: "200 minus 20% minus 10%"},
+			want: want{tree: "((200 minus 20%) minus 10%)", offset: 0, err: nil},
+		},
+		{
+			name: "plain subtraction",
+			in:   in{s: "200 minus 20 - 10"},
+			want: want{tree: "((200 - 20) - 10)", offset: 0, err: nil},
+		},
+		{
+			name: "change",
+			in:   in{s: "percent change from 80 to 100"},
+			want: want{tree: "(change from 80 to 100)", offset: 0, err: nil},
+		},
+		{
+			name: "precedence",
+			in:   in{s: "1 + 2 * 3% of $1 divided by -x"},
+			want: want{tree: "(1 + ((2 * (3% of $1)) / -x))", offset: 0, err: nil},
+		},
+		{name: "parentheses", in: in{s: "(1 + 2)% of 50"}, want: want{tree: "((1 + 2)% of 50)", offset: 0, err: nil}},
+		{name: "assignment", in: in{s: "a = 240"}, want: want{tree: "a = 240", offset: 0, err: nil}},
+		{name: "empty", in: in{s: " "}, want: want{tree: "", offset: 1, err: percent.ErrSyntax}},
+		{name: "unexpected character", in: in{s: "15 # 2"}, want: want{tree: "", offset: 3, err: percent.ErrSyntax}},
+		{name: "missing operand", in: in{s: "15% of"}, want: want{tree: "", offset: 6, err: percent.ErrSyntax}},
+		{
+			name: "missing of",
+			in:   in{s: "what percent is 30 in 120"},
+			want: want{tree: "", offset: 19, err: percent.ErrSyntax},
+		},
+		{name: "missing by", in: in{s: "80 increased 5%"}, want: want{tree: "", offset: 13, err: percent.ErrSyntax}},
+		{name: "unclosed parenthesis", in: in{s: "(1 + 2"}, want: want{tree: "", offset: 6, err: percent.ErrSyntax}},
+		{name: "keyword as name", in: in{s: "of = 2"}, want: want{tree: "", offset: 0, err: percent.ErrSyntax}},
+		{name: "trailing token", in: in{s: "15% of 240 to"}, want: want{tree: "", offset: 11, err: percent.ErrSyntax}},
+		{name: "reference without digits", in: in{s: "$a"}, want: want{tree: "", offset: 1, err: percent.ErrSyntax}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			got, err := expr.Parse(tt.in.s)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Fatalf("Parse(%q) error = %v, want err %v", tt.in.s, err, tt.want.err)
+			}
+
+			if err != nil {
+				var se *percent.SyntaxError
+				if !errors.As(err, &se) || se.Offset != tt.want.offset {
+					t.Errorf("Parse(%q) error = %v, want offset %d", tt.in.s, err, tt.want.offset)
+				}
+
+				return
+			}
+
+			if got.String() != tt.want.tree {
+				t.Errorf("Parse(%q) = %s, want %s", tt.in.s, got, tt.want.tree)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s    string
+		env  expr.Env
+		opts []percent.Option
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "percent of", in: in{s: "15% of 240", env: nil, opts: nil}, want: want{value: 36, err: nil}},
+		{name: "of", in: in{s: "what percent is 30 of 120", env: nil, opts: nil}, want: want{value: 25, err: nil}},
+		{name: "increase", in: in{s: "80 increased by 12.5%", env: nil, opts: nil}, want: want{value: 90, err: nil}},
+		{name: "plus percent", in: in{s: "80 + 25%", env: nil, opts: nil}, want: want{value: 100, err: nil}},
+		{
+			name: "increase over 100 percent",
+			in:   in{s: "80 increased by 150%", env: nil, opts: nil},
+			want: want{value: 200, err: nil},
+		},
+		{
+			name: "increase over 100 percent with precision",
+			in:   in{s: "3 plus 250%", env: nil, opts: []percent.Option{percent.WithPrecision(0)}},
+			want: want{value: 11, err: nil},
+		},
+		{
+			name: "increase not finite",
+			in:   in{s: "x increased by 200%", env: expr.Env{"x": math.MaxFloat64}, opts: nil},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+		{name: "remain", in: in{s: "200 minus 20% minus 10%", env: nil, opts: nil}, want: want{value: 144, err: nil}},
+		{name: "change", in: in{s: "change from 80 to 100", env: nil, opts: nil}, want: want{value: 25, err: nil}},
+		{name: "arithmetic", in: in{s: "-(2 + 4) * 3 / 2", env: nil, opts: nil}, want: want{value: -9, err: nil}},
+		{name: "lone percentage", in: in{s: "12.5%", env: nil, opts: nil}, want: want{value: 12.5, err: nil}},
+		{
+			name: "names",
+			in:   in{s: "$1 is what percent of total", env: expr.Env{"$1": 30, "total": 120}, opts: nil},
+			want: want{value: 25, err: nil},
+		},
+		{
+			name: "options",
+			in:   in{s: "what percent is 1 of 3", env: nil, opts: []percent.Option{percent.WithPrecision(2)}},
+			want: want{value: 33.33, err: nil},
+		},
+		{name: "undefined", in: in{s: "10% of x", env: expr.Env{}, opts: nil}, want: want{value: 0, err: expr.ErrUndefined}},
+		{
+			name: "division by zero",
+			in:   in{s: "1 / (2 - 2)", env: nil, opts: nil},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{name: "out of range", in: in{s: "150% of 2", env: nil, opts: nil}, want: want{value: 0, err: percent.ErrOutOfRange}},
+		{
+			name: "part greater than total",
+			in:   in{s: "what percent is 3 of 2", env: nil, opts: nil},
+			want: want{value: 0, err: percent.ErrPartGreaterThanTotal},
+		},
+		{name: "syntax", in: in{s: "15% of", env: nil, opts: nil}, want: want{value: 0, err: percent.ErrSyntax}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			got, err := expr.Evaluate(tt.in.s, tt.in.env, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Evaluate(%q) error = %v, want err %v", tt.in.s, err, tt.want.err)
+			}
+
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.in.s, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestEvalAssignment(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	env := expr.Env{}
+
+	// Act
+	_, err := expr.Evaluate("price = 80", env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := expr.Evaluate("price increased by 25%", env)
+
+	// Assert
+	if err != nil || got != 100 || env["price"] != 80 {
+		t.Errorf("Evaluate() = %v, %v with price %v, want 100, nil with price 80", got, err, env["price"])
+	}
+}
+
+func TestTrace(t *testing.T) {
+	t.Parallel()
+
+	type step struct {
+		node        string
+		x, y, value float64
+	}
+
+	type want struct {
+		value float64
+		steps []step
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{
+			name: "successive decreases",
+			in:   "200 minus 20% minus 10%",
+			want: want{
+				value: 144,
+				steps: []step{
+					{node: "(200 minus 20%)", x: 20, y: 200, value: 160},
+					{node: "((200 minus 20%) minus 10%)", x: 10, y: 160, value: 144},
+				},
+				err: nil,
+			},
+		},
+		{
+			name: "arithmetic",
+			in:   "-(15% of 240) * 2",
+			want: want{
+				value: -72,
+				steps: []step{
+					{node: "(15% of 240)", x: 15, y: 240, value: 36},
+					{node: "(-(15% of 240) * 2)", x: -36, y: 2, value: -72},
+				},
+				err: nil,
+			},
+		},
+		{
+			name: "failure",
+			in:   "(change from 80 to 100) / (5 - 5)",
+			want: want{
+				value: 0,
+				steps: []step{
+					{node: "(change from 80 to 100)", x: 80, y: 100, value: 25},
+					{node: "(5 - 5)", x: 5, y: 5, value: 0},
+				},
+				err: percent.ErrDivideByZero,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			n, err := expr.Parse(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			got, steps, err := expr.Trace(n, nil)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) || got != tt.want.value {
+				t.Errorf("Trace(%s) = %v, %v, want %v, %v", n, got, err, tt.want.value, tt.want.err)
+			}
+
+			var gotSteps []step
+			for _, s := range steps {
+				gotSteps = append(gotSteps, step{node: s.Node.String(), x: s.X, y: s.Y, value: s.Value})
+			}
+
+			if diff := cmp.Diff(tt.want.steps, gotSteps, cmp.AllowUnexported(step{})); diff != "" {
+				t.Errorf("Trace(%s) steps mismatch (-want +got):\n%s", n, diff)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []string{
+		"15% of 240",                // percent of
+		"what percent is 30 of 120", // question
+		"30 is what percent of 120", // trailing question
+		"200 minus 20% minus 10%",   // successive decreases
+		"-(1 + 2)% of -$1 / x",      // negation and parentheses
+		"a = change from 1 to 2",    // assignment
+		"((5%)%",                    // malformed (should error)
+	}
+	for _, tc := range testcases {
+		f.Add(tc) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// Arrange
+		// No special arrangement needed
+
+		// Act
+		n, err := expr.Parse(s)
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: Errors are syntax errors with an offset within s
+		// Property 3: The canonical form of a tree parses back to the same tree
+
+		if err != nil {
+			var se *percent.SyntaxError
+			if !errors.As(err, &se) || se.Offset < 0 || se.Offset > len(s) {
+				t.Fatalf("Parse(%q) returned unexpected error: %v", s, err)
+			}
+
+			return
+		}
+
+		again, err := expr.Parse(n.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) of the canonical form of %q error = %v", n, s, err)
+		}
+
+		if again.String() != n.String() {
+			t.Errorf("Parse(%q) = %s, want %s", n, again, n)
+		}
+	})
+}
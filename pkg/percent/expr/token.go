@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// Kind is the kind of a Token.
+type Kind int
+
+const (
+	// EOF marks the end of the input.
+	EOF Kind = iota
+	// Number is a decimal number such as "12.5".
+	Number
+	// Word is a name or a keyword such as "of", matched without regard to case.
+	Word
+	// Ref is a reference to a previous result such as "$1".
+	Ref
+	// Percent is the percent sign "%".
+	Percent
+	// Plus is "+".
+	Plus
+	// Minus is "-".
+	Minus
+	// Star is "*".
+	Star
+	// Slash is "/".
+	Slash
+	// LParen is "(".
+	LParen
+	// RParen is ")".
+	RParen
+	// Assign is "=".
+	Assign
+)
+
+// String returns the name of k, e.g. "number".
+func (k Kind) String() string {
+	switch k {
+	case EOF:
+		return "end of input"
+	case Number:
+		return "number"
+	case Word:
+		return "word"
+	case Ref:
+		return "reference"
+	case Percent, Plus, Minus, Star, Slash, LParen, RParen, Assign:
+		return strconv.Quote(symbols[k-Percent : k-Percent+1])
+	}
+
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// symbols holds the text of the single-character tokens in the order of their kinds, from
+// Percent to Assign.
+const symbols = "%+-*/()="
+
+// Token is a lexical token of an expression.
+type Token struct {
+	// Kind is the kind of the token.
+	Kind Kind
+	// Text is the text of the token as written in the input.
+	Text string
+	// Offset is the byte offset of the token in the input.
+	Offset int
+}
+
+// String returns a description of t for error messages, e.g. `"of"` or "end of input".
+func (t Token) String() string {
+	if t.Kind == EOF {
+		return t.Kind.String()
+	}
+
+	return strconv.Quote(t.Text)
+}
+
+// Tokenize splits s into tokens, ending with an EOF token. Numbers are written with ASCII
+// digits and an optional decimal point, words start with a letter or underscore, and
+// references are a dollar sign followed by digits.
+//
+// Tokenize returns a *percent.SyntaxError if s holds a character that starts no token.
+func Tokenize(s string) ([]Token, error) {
+	var toks []Token
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+			continue
+		case isDigit(r) || r == '.' && i+1 < len(s) && isDigit(rune(s[i+1])):
+			n := scanNumber(s[i:])
+			toks = append(toks, Token{Kind: Number, Text: s[i : i+n], Offset: i})
+			i += n
+
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			n := scanWord(s[i:])
+			toks = append(toks, Token{Kind: Word, Text: s[i : i+n], Offset: i})
+			i += n
+
+			continue
+		case r == '$':
+			n := strings.IndexFunc(s[i+1:], func(r rune) bool { return !isDigit(r) })
+			if n < 0 {
+				n = len(s) - i - 1
+			}
+
+			if n == 0 {
+				return nil, syntaxError(s, i+1, resource.ExpectedDigitMessage)
+			}
+
+			toks = append(toks, Token{Kind: Ref, Text: s[i : i+1+n], Offset: i})
+			i += 1 + n
+
+			continue
+		}
+
+		k := strings.IndexRune(symbols, r)
+		if k < 0 {
+			return nil, syntaxError(s, i, resource.UnexpectedCharacterMessage)
+		}
+
+		toks = append(toks, Token{Kind: Percent + Kind(k), Text: s[i : i+size], Offset: i})
+		i += size
+	}
+
+	return append(toks, Token{Kind: EOF, Text: "", Offset: len(s)}), nil
+}
+
+// scanNumber returns the length of the number at the start of s.
+func scanNumber(s string) int {
+	n, dot := 0, false
+
+	for n < len(s) && (isDigit(rune(s[n])) || s[n] == '.' && !dot) {
+		dot = dot || s[n] == '.'
+		n++
+	}
+
+	return n
+}
+
+// scanWord returns the length of the word at the start of s.
+func scanWord(s string) int {
+	n := strings.IndexFunc(s, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !isDigit(r)
+	})
+	if n < 0 {
+		return len(s)
+	}
+
+	return n
+}
+
+// isDigit reports whether r is an ASCII digit.
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// syntaxError returns a *percent.SyntaxError at offset in s.
+func syntaxError(s string, offset int, msg string) error {
+	return &percent.SyntaxError{Input: s, Offset: offset, Msg: msg}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package expr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+	"github.com/sentenz/percent/pkg/percent/expr"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		tokens []expr.Token
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "expression",
+			in:   in{s: "x = 12.5% of $10"},
+			want: want{
+				tokens: []expr.Token{
+					{Kind: expr.Word, Text: "x", Offset: 0},
+					{Kind: expr.Assign, Text: "=", Offset: 2},
+					{Kind: expr.Number, Text: "12.5", Offset: 4},
+					{Kind: expr.Percent, Text: "%", Offset: 8},
+					{Kind: expr.Word, Text: "of", Offset: 10},
+					{Kind: expr.Ref, Text: "$10", Offset: 13},
+					{Kind: expr.EOF, Text: "", Offset: 16},
+				},
+				err: nil,
+			},
+		},
+		{
+			name: "symbols",
+			in:   in{s: "(-.5)*+/"},
+			want: want{
+				tokens: []expr.Token{
+					{Kind: expr.LParen, Text: "(", Offset: 0},
+					{Kind: expr.Minus, Text: "-", Offset: 1},
+					{Kind: expr.Number, Text: ".5", Offset: 2},
+					{Kind: expr.RParen, Text: ")", Offset: 4},
+					{Kind: expr.Star, Text: "*", Offset: 5},
+					{Kind: expr.Plus, Text: "+", Offset: 6},
+					{Kind: expr.Slash, Text: "/", Offset: 7},
+					{Kind: expr.EOF, Text: "", Offset: 8},
+				},
+				err: nil,
+			},
+		},
+		{
+			name: "words",
+			in:   in{s: "Größe_2 3a"},
+			want: want{
+				tokens: []expr.Token{
+					{Kind: expr.Word, Text: "Größe_2", Offset: 0},
+					{Kind: expr.Number, Text: "3", Offset: 10},
+					{Kind: expr.Word, Text: "a", Offset: 11},
+					{Kind: expr.EOF, Text: "", Offset: 12},
+				},
+				err: nil,
+			},
+		},
+		{name: "empty", in: in{s: ""}, want: want{tokens: []expr.Token{{Kind: expr.EOF, Text: "", Offset: 0}}, err: nil}},
+		{name: "unexpected character", in: in{s: "1 & 2"}, want: want{tokens: nil, err: percent.ErrSyntax}},
+		{name: "lone dollar", in: in{s: "$"}, want: want{tokens: nil, err: percent.ErrSyntax}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			got, err := expr.Tokenize(tt.in.s)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Tokenize(%q) error = %v, want err %v", tt.in.s, err, tt.want.err)
+			}
+
+			if diff := cmp.Diff(tt.want.tokens, got); diff != "" {
+				t.Errorf("Tokenize(%q) mismatch (-want +got):\n%s", tt.in.s, diff)
+			}
+		})
+	}
+}
+
+func TestKindString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   expr.Kind
+		want string
+	}{
+		{name: "eof", in: expr.EOF, want: "end of input"},
+		{name: "number", in: expr.Number, want: "number"},
+		{name: "percent", in: expr.Percent, want: `"%"`},
+		{name: "assign", in: expr.Assign, want: `"="`},
+		{name: "unknown", in: expr.Kind(42), want: "Kind(42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			got := tt.in.String()
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// The percent types implement flag.Getter, so they can be used with flag.Var. Set accepts the
+// notations understood by Parse, where a number without a unit is read in the unit of the
+// type, and returns an *Error wrapping ErrOutOfRange if the value is not within the range of
+// the type, e.g. [0, 100] for Percentage.
+
+// Set implements flag.Value.
+func (p *Percentage) Set(s string) error {
+	return p.wire().set(s, (*float64)(p))
+}
+
+// Get implements flag.Getter.
+func (p *Percentage) Get() any {
+	return *p
+}
+
+// Set implements flag.Value.
+func (r *Ratio) Set(s string) error {
+	return r.wire().set(s, (*float64)(r))
+}
+
+// Get implements flag.Getter.
+func (r *Ratio) Get() any {
+	return *r
+}
+
+// Set implements flag.Value.
+func (b *BasisPoints) Set(s string) error {
+	return b.wire().set(s, (*float64)(b))
+}
+
+// Get implements flag.Getter.
+func (b *BasisPoints) Get() any {
+	return *b
+}
+
+// Set implements flag.Value.
+func (m *PerMille) Set(s string) error {
+	return m.wire().set(s, (*float64)(m))
+}
+
+// Get implements flag.Getter.
+func (m *PerMille) Get() any {
+	return *m
+}
+
+// Set implements flag.Value.
+func (m *PPM) Set(s string) error {
+	return m.wire().set(s, (*float64)(m))
+}
+
+// Get implements flag.Getter.
+func (m *PPM) Get() any {
+	return *m
+}
+
+// FlagVar defines a flag in fs with the given name, default value and usage, and stores its
+// value in p. The usage message is completed with the valid range and an example, so that
+// usage "share of canary traffic" for a Percentage is printed as
+//
+//	-canary-percent percentage
+//	    	share of canary traffic, a percentage in [0, 100] such as 25% (default 5%)
+func FlagVar[T Unit](fs *flag.FlagSet, p *T, name string, value T, usage string) {
+	*p = value
+	v, _ := any(p).(flag.Value)
+
+	fs.Var(v, name, flagUsage[T](usage))
+}
+
+// Flag defines a flag in fs like FlagVar and returns the address of its value.
+func Flag[T Unit](fs *flag.FlagSet, name string, value T, usage string) *T {
+	p := new(T)
+	FlagVar(fs, p, name, value, usage)
+
+	return p
+}
+
+// Getenv returns the value of the environment variable key as T, or value if the variable is
+// unset or empty. It accepts the same input as the flags of T, and returns an error naming
+// the variable if it is malformed or out of range.
+func Getenv[T Unit](key string, value T) (T, error) {
+	s, ok := os.LookupEnv(key)
+	if !ok || s == "" {
+		return value, nil
+	}
+
+	w := value.wire()
+
+	var x float64
+	if err := w.parse(w.name+".Getenv", s, w, &x); err != nil {
+		return value, fmt.Errorf(resource.EnvErrorFormat, key, err)
+	}
+
+	return T(x), nil
+}
+
+// flagUsage completes usage with the range of T and an example value.
+func flagUsage[T Unit](usage string) string {
+	var zero T
+
+	w := zero.wire()
+	example, _ := T(w.hi / 4).MarshalText()
+
+	return strings.TrimPrefix(fmt.Sprintf(resource.FlagUsageFormat, usage, w.arg, 0.0, w.hi, example), ", ")
+}
+
+// set parses s into dst for flag.Value.
+func (w wire) set(s string, dst *float64) error {
+	return w.parse(w.name+".Set", s, w, dst)
+}
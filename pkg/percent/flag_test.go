@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestFlag(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		args []string
+	}
+
+	type want struct {
+		canary    percent.Percentage
+		threshold percent.Ratio
+		spread    percent.BasisPoints
+		err       error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "defaults",
+			in:   in{args: nil},
+			want: want{canary: 5, threshold: 0.025, spread: 10, err: nil},
+		},
+		{
+			name: "bare numbers in the unit of the flag",
+			in:   in{args: []string{"--canary-percent=12.5", "--threshold=0.5", "--spread=25"}},
+			want: want{canary: 12.5, threshold: 0.5, spread: 25, err: nil},
+		},
+		{
+			name: "other notations",
+			in:   in{args: []string{"--canary-percent=250bp", "--threshold=2.5%", "--spread=0.3%"}},
+			want: want{canary: 2.5, threshold: 0.025, spread: 30, err: nil},
+		},
+		{
+			name: "above the range",
+			in:   in{args: []string{"--canary-percent=150%"}},
+			want: want{canary: 5, threshold: 0.025, spread: 10, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "below the range",
+			in:   in{args: []string{"--threshold=-1%"}},
+			want: want{canary: 5, threshold: 0.025, spread: 10, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "malformed",
+			in:   in{args: []string{"--spread=wide"}},
+			want: want{canary: 5, threshold: 0.025, spread: 10, err: percent.ErrSyntax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+
+			var threshold percent.Ratio
+
+			canary := percent.Flag[percent.Percentage](fs, "canary-percent", 5, "share of canary traffic")
+			percent.FlagVar(fs, &threshold, "threshold", 0.025, "error threshold")
+			spread := percent.Flag[percent.BasisPoints](fs, "spread", 10, "")
+
+			// Act
+			err := fs.Parse(tt.in.args)
+
+			// Assert
+			// The flag package formats the error of Set with %v, which does not wrap it.
+			if (err == nil) != (tt.want.err == nil) || err != nil && !strings.Contains(err.Error(), tt.want.err.Error()) {
+				t.Errorf("Parse(%q) error = %v, want err %v", tt.in.args, err, tt.want.err)
+			}
+			if *canary != tt.want.canary || threshold != tt.want.threshold || *spread != tt.want.spread {
+				t.Errorf("Parse(%q) = %v, %v, %v, want %v, %v, %v", tt.in.args, *canary, threshold, *spread,
+					tt.want.canary, tt.want.threshold, tt.want.spread)
+			}
+		})
+	}
+}
+
+func TestFlagUsage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var out strings.Builder
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+
+	percent.Flag[percent.Percentage](fs, "canary-percent", 5, "share of canary traffic")
+	percent.Flag[percent.Ratio](fs, "threshold", 0, "error threshold")
+	percent.Flag[percent.PerMille](fs, "sample", 1, "")
+
+	want := `  -canary-percent percentage
+    	share of canary traffic, a percentage in [0, 100] such as 25% (default 5%)
+  -sample per-mille
+    	a per-mille in [0, 1000] such as 250‰ (default 1‰)
+  -threshold ratio
+    	error threshold, a ratio in [0, 1] such as 0.25
+`
+
+	// Act
+	fs.PrintDefaults()
+
+	// Assert
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("PrintDefaults() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFlagGetter(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	p := percent.PPM(7000)
+
+	var getter flag.Getter = &p
+
+	// Act
+	err := getter.Set("1%")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	if got := getter.Get(); got != percent.PPM(10000) {
+		t.Errorf("Get() = %v, want 10000ppm", got)
+	}
+	if got := getter.String(); got != "10000ppm" {
+		t.Errorf("String() = %q, want %q", got, "10000ppm")
+	}
+}
+
+//nolint:paralleltest // t.Setenv does not allow parallel tests.
+func TestGetenv(t *testing.T) {
+	type in struct {
+		value string
+		set   bool
+	}
+
+	type want struct {
+		value percent.Percentage
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "unset",
+			in:   in{value: "", set: false},
+			want: want{value: 1, err: nil},
+		},
+		{
+			name: "empty",
+			in:   in{value: "", set: true},
+			want: want{value: 1, err: nil},
+		},
+		{
+			name: "percent sign",
+			in:   in{value: "2.5%", set: true},
+			want: want{value: 2.5, err: nil},
+		},
+		{
+			name: "bare number",
+			in:   in{value: "2.5", set: true},
+			want: want{value: 2.5, err: nil},
+		},
+		{
+			name: "out of range",
+			in:   in{value: "101", set: true},
+			want: want{value: 1, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "malformed",
+			in:   in{value: "high", set: true},
+			want: want{value: 1, err: percent.ErrSyntax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			const key = "PERCENT_TEST_ERROR_THRESHOLD"
+			if tt.in.set {
+				t.Setenv(key, tt.in.value)
+			}
+
+			// Act
+			got, err := percent.Getenv[percent.Percentage](key, 1)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Getenv() error = %v, want err %v", err, tt.want.err)
+			}
+			if err != nil && !strings.Contains(err.Error(), key) {
+				t.Errorf("Getenv() error = %v, want it to name %s", err, key)
+			}
+			if got != tt.want.value {
+				t.Errorf("Getenv() = %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
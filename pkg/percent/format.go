@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Unit symbols appended when formatting the percent types.
+const (
+	percentSymbol     = "%"
+	basisPointsSymbol = "bp"
+	perMilleSymbol    = "‰"
+	ppmSymbol         = "ppm"
+	pointsSymbol      = "pp"
+)
+
+// defaultPrecision is the precision of the %e and %f verbs, as for float64.
+const defaultPrecision = 6
+
+// String returns p in its shortest decimal form followed by a percent sign, e.g. "12.5%".
+func (p Percentage) String() string {
+	return shortest(float64(p)) + percentSymbol
+}
+
+// Format implements fmt.Formatter. See formatUnit for the supported verbs and flags.
+func (p Percentage) Format(f fmt.State, verb rune) {
+	formatUnit(f, verb, p, float64(p), percentSymbol, float64(p))
+}
+
+// String returns r in its shortest decimal form, e.g. "0.125".
+func (r Ratio) String() string {
+	return shortest(float64(r))
+}
+
+// Format implements fmt.Formatter. See formatUnit for the supported verbs and flags.
+func (r Ratio) Format(f fmt.State, verb rune) {
+	formatUnit(f, verb, r, float64(r), "", float64(r.Percentage()))
+}
+
+// String returns b in its shortest decimal form followed by "bp", e.g. "1250bp".
+func (b BasisPoints) String() string {
+	return shortest(float64(b)) + basisPointsSymbol
+}
+
+// Format implements fmt.Formatter. See formatUnit for the supported verbs and flags.
+func (b BasisPoints) Format(f fmt.State, verb rune) {
+	formatUnit(f, verb, b, float64(b), basisPointsSymbol, float64(b.Percentage()))
+}
+
+// String returns m in its shortest decimal form followed by a per mille sign, e.g. "125‰".
+func (m PerMille) String() string {
+	return shortest(float64(m)) + perMilleSymbol
+}
+
+// Format implements fmt.Formatter. See formatUnit for the supported verbs and flags.
+func (m PerMille) Format(f fmt.State, verb rune) {
+	formatUnit(f, verb, m, float64(m), perMilleSymbol, float64(m.Percentage()))
+}
+
+// String returns m in its shortest decimal form followed by "ppm", e.g. "125000ppm".
+func (m PPM) String() string {
+	return shortest(float64(m)) + ppmSymbol
+}
+
+// Format implements fmt.Formatter. See formatUnit for the supported verbs and flags.
+func (m PPM) Format(f fmt.State, verb rune) {
+	formatUnit(f, verb, m, float64(m), ppmSymbol, float64(m.Percentage()))
+}
+
+// formatUnit writes value, the numeric value of v, followed by symbol to f. Points is the
+// value in percent. The verbs are:
+//
+//	%v %s  shortest decimal form, or fixed-point if a precision is given
+//	%q     %v as a double-quoted string
+//	%e %E %f %F %g %G  as for float64
+//	%P     the value in percentage points, e.g. "+2.5pp" with %+P
+//
+// The '+' flag always prints a sign, the ' ' flag leaves a space for an omitted sign and the
+// '#' flag omits the unit symbol. Width pads the whole result, including the symbol, and the
+// '-' flag pads on the right.
+func formatUnit(f fmt.State, verb rune, v any, value float64, symbol string, points float64) {
+	var s string
+
+	switch verb {
+	case 'v', 's', 'q':
+		s = formatNumber(f, 'f', -1, value)
+	case 'e', 'E', 'f':
+		s = formatNumber(f, byte(verb), defaultPrecision, value)
+	case 'F':
+		s = formatNumber(f, 'f', defaultPrecision, value)
+	case 'g', 'G':
+		s = formatNumber(f, byte(verb), -1, value)
+	case 'P':
+		s, symbol = formatNumber(f, 'f', -1, points), pointsSymbol
+	default:
+		fmt.Fprintf(f, "%%!%c(%T=%s)", verb, v, shortest(value))
+
+		return
+	}
+
+	if !f.Flag('#') {
+		s += symbol
+	}
+
+	if verb == 'q' {
+		s = strconv.Quote(s)
+	}
+
+	pad(f, s)
+}
+
+// formatNumber formats x as strconv.FormatFloat does, honoring the precision and sign flags of
+// f. Prec is used if f has no precision.
+func formatNumber(f fmt.State, verb byte, prec int, x float64) string {
+	if p, ok := f.Precision(); ok {
+		prec = p
+	}
+
+	s := strconv.FormatFloat(x, verb, prec, 64)
+
+	if s[0] != '-' && s[0] != '+' {
+		switch {
+		case f.Flag('+'):
+			s = "+" + s
+		case f.Flag(' '):
+			s = " " + s
+		}
+	}
+
+	return s
+}
+
+// pad writes s to f, padded with spaces to the width of f.
+func pad(f fmt.State, s string) {
+	w, ok := f.Width()
+	if n := utf8.RuneCountInString(s); ok && n < w {
+		fill := strings.Repeat(" ", w-n)
+		if f.Flag('-') {
+			s += fill
+		} else {
+			s = fill + s
+		}
+	}
+
+	_, _ = f.Write([]byte(s))
+}
+
+// shortest returns x in the shortest decimal form that reads back to the same value, without
+// an exponent.
+func shortest(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		format string
+		value  any
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want string
+	}{
+		{name: "percentage default", in: in{format: "%v", value: percent.Percentage(12.5)}, want: "12.5%"},
+		{name: "percentage string", in: in{format: "%s", value: percent.Percentage(25)}, want: "25%"},
+		{name: "percentage precision", in: in{format: "%.2f", value: percent.Percentage(100.0 / 3)}, want: "33.33%"},
+		{name: "percentage default f", in: in{format: "%f", value: percent.Percentage(25)}, want: "25.000000%"},
+		{name: "percentage value precision", in: in{format: "%.1v", value: percent.Percentage(2.25)}, want: "2.2%"},
+		{name: "percentage explicit sign", in: in{format: "%+.1f", value: percent.Percentage(5)}, want: "+5.0%"},
+		{name: "percentage negative sign", in: in{format: "%+v", value: percent.Percentage(-5)}, want: "-5%"},
+		{name: "percentage space flag", in: in{format: "% v", value: percent.Percentage(5)}, want: " 5%"},
+		{name: "percentage no symbol", in: in{format: "%#.2f", value: percent.Percentage(5)}, want: "5.00"},
+		{name: "percentage width", in: in{format: "%8.1f|", value: percent.Percentage(5)}, want: "    5.0%|"},
+		{name: "percentage left aligned", in: in{format: "%-8.1f|", value: percent.Percentage(5)}, want: "5.0%    |"},
+		{name: "percentage exponent", in: in{format: "%.1e", value: percent.Percentage(1500)}, want: "1.5e+03%"},
+		{name: "percentage g", in: in{format: "%g", value: percent.Percentage(0.5)}, want: "0.5%"},
+		{name: "percentage quoted", in: in{format: "%q", value: percent.Percentage(5)}, want: `"5%"`},
+		{name: "percentage points", in: in{format: "%+.1P", value: percent.Percentage(2.5)}, want: "+2.5pp"},
+		{name: "percentage bad verb", in: in{format: "%d", value: percent.Percentage(5)}, want: "%!d(percent.Percentage=5)"},
+		{name: "ratio default", in: in{format: "%v", value: percent.Ratio(0.125)}, want: "0.125"},
+		{name: "ratio points", in: in{format: "%P", value: percent.Ratio(0.125)}, want: "12.5pp"},
+		{name: "basis points default", in: in{format: "%v", value: percent.BasisPoints(250)}, want: "250bp"},
+		{name: "basis points points", in: in{format: "%.2P", value: percent.BasisPoints(250)}, want: "2.50pp"},
+		{name: "per mille default", in: in{format: "%v", value: percent.PerMille(3)}, want: "3‰"},
+		{name: "per mille width counts runes", in: in{format: "%4v|", value: percent.PerMille(3)}, want: "  3‰|"},
+		{name: "ppm default", in: in{format: "%v", value: percent.PPM(7000)}, want: "7000ppm"},
+		{name: "large value without exponent", in: in{format: "%v", value: percent.Percentage(1e21)}, want: "1000000000000000000000%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got := fmt.Sprintf(tt.in.format, tt.in.value)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("Sprintf(%q, %v) = %q, want %q", tt.in.format, tt.in.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	values := []percent.Percentage{0, 0.07, 12.5, 100.0 / 3, 99.99, 100}
+
+	for _, p := range values {
+		// Arrange
+
+		// Act
+		got, err := percent.Parse(p.String())
+		gotBP, errBP := percent.Parse(p.BasisPoints().String())
+		gotRatio, errRatio := percent.ParseRatio(p.Ratio().String())
+
+		// Assert
+		if err != nil || got != p {
+			t.Errorf("Parse(%q) = %v, %v, want %v", p.String(), got, err, float64(p))
+		}
+		if errBP != nil || gotBP != p {
+			t.Errorf("Parse(%q) = %v, %v, want %v", p.BasisPoints().String(), gotBP, errBP, float64(p))
+		}
+		if errRatio != nil || gotRatio != p.Ratio() {
+			t.Errorf("ParseRatio(%q) = %v, %v, want %v", p.Ratio().String(), gotRatio, errRatio, p.Ratio())
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// PercentInt returns the percentage of value like Percent, but computes value*percent/100
+// exactly with 128-bit intermediates and returns the result in the type of value. The result
+// is rounded to a whole number using the mode selected by WithRounding; WithPrecision has no
+// effect. With WithSaturation, results that do not fit in T are clamped to its bounds instead
+// of returning ErrOverflow.
+//
+// PercentInt returns an *Error wrapping ErrOutOfRange if percent is rejected by the Policy,
+// by default if it is not within [0, 100].
+func PercentInt[T constraints.Integer](percent, value T, opts ...Option) (T, error) {
+	o := newOptions(opts)
+
+	p, pneg, err := intPercent("PercentInt", percent, value, o)
+	if err != nil {
+		return 0, err
+	}
+
+	v, vneg := magnitude(value)
+
+	return intResult("PercentInt", v, p, resource.PercentMax, vneg != pneg, o, percent, value)
+}
+
+// RemainInt returns the percentage of value that remains after subtracting percent like
+// Remain, computed exactly in the type of value as PercentInt does.
+//
+// RemainInt returns an *Error wrapping ErrOutOfRange if percent is rejected by the Policy, by
+// default if it is not within [0, 100].
+func RemainInt[T constraints.Integer](percent, value T, opts ...Option) (T, error) {
+	o := newOptions(opts)
+
+	p, pneg, err := intPercent("RemainInt", percent, value, o)
+	if err != nil {
+		return 0, err
+	}
+
+	// The remaining percentage is 100-percent, which is negative for percentages above 100.
+	r, rneg := resource.PercentMax-p, false
+
+	switch {
+	case pneg:
+		r = resource.PercentMax + p
+	case p > resource.PercentMax:
+		r, rneg = p-resource.PercentMax, true
+	}
+
+	v, vneg := magnitude(value)
+
+	return intResult("RemainInt", v, r, resource.PercentMax, vneg != rneg, o, percent, value)
+}
+
+// OfInt calculates the percentage of part relative to total like Of, computed exactly in the
+// type of its arguments as PercentInt does.
+//
+// OfInt returns an *Error wrapping ErrDivideByZero if total is zero, one wrapping
+// ErrPartGreaterThanTotal if part exceeds total under the Strict policy, and one wrapping
+// ErrOverflow if the result does not fit in T.
+func OfInt[T constraints.Integer](part, total T, opts ...Option) (T, error) {
+	o := newOptions(opts)
+
+	if total == 0 {
+		return 0, newError("OfInt", ErrDivideByZero, float64(part), float64(total))
+	}
+
+	if o.policy != Clamp && !o.unbounded() && part > total {
+		return 0, &Error{
+			Op: "OfInt", Inputs: []float64{float64(part), float64(total)}, Min: math.Inf(-1),
+			Max: float64(total), Err: ErrPartGreaterThanTotal,
+		}
+	}
+
+	p, pneg := magnitude(part)
+	t, tneg := magnitude(total)
+
+	if o.policy == Clamp {
+		switch {
+		case p == 0 || pneg != tneg:
+			return 0, nil
+		case p >= t:
+			return resource.PercentMax, nil
+		}
+	}
+
+	return intResult("OfInt", p, resource.PercentMax, t, pneg != tneg, o, part, total)
+}
+
+// ChangeInt calculates the percentage change between two values like Change, computed exactly
+// in the type of its arguments as PercentInt does.
+//
+// ChangeInt returns an *Error wrapping ErrDivideByZero if oldValue is zero and one wrapping
+// ErrOverflow if the result does not fit in T. WithZeroBaseline and WithEpsilon are ignored,
+// as the results they select for a zero old value, such as infinities, are not integers.
+func ChangeInt[T constraints.Integer](oldValue, newValue T, opts ...Option) (T, error) {
+	o := newOptions(opts)
+
+	if oldValue == 0 {
+		return 0, newError("ChangeInt", ErrDivideByZero, float64(oldValue), float64(newValue))
+	}
+
+	old, oneg := magnitude(oldValue)
+	n, nneg := magnitude(newValue)
+
+	// The difference of two values of the same integer type always fits in 64 bits.
+	d, dneg := n-old, nneg
+
+	switch {
+	case oneg != nneg:
+		d = n + old
+	case n < old:
+		d, dneg = old-n, !nneg
+	}
+
+	return intResult("ChangeInt", d, resource.PercentMax, old, dneg, o, oldValue, newValue)
+}
+
+// intPercent returns the magnitude of percent and whether it is negative, after applying the
+// policy of o. It returns an *Error wrapping ErrOutOfRange if the policy rejects percent.
+func intPercent[T constraints.Integer](op string, percent, value T, o options) (uint64, bool, error) {
+	hi := math.Inf(1)
+	if !o.unbounded() {
+		hi = resource.PercentMax
+	}
+
+	switch {
+	case o.policy == AllowNegative:
+	case percent < resource.PercentMin && o.policy == Clamp:
+		percent = resource.PercentMin
+	case percent > resource.PercentMax && o.policy == Clamp:
+		percent = resource.PercentMax
+	case percent < resource.PercentMin || float64(percent) > hi:
+		return 0, false, &Error{
+			Op: op, Inputs: []float64{float64(percent), float64(value)}, Min: resource.PercentMin,
+			Max: hi, Err: ErrOutOfRange,
+		}
+	}
+
+	p, neg := magnitude(percent)
+
+	return p, neg, nil
+}
+
+// intResult returns a*b/c rounded according to o, negated if neg, in the type of inputs. It
+// returns an *Error wrapping ErrOverflow if the result does not fit.
+func intResult[T constraints.Integer](
+	op string, a, b, c uint64, neg bool, o options, inputs ...T,
+) (T, error) {
+	m, ok := mulDiv(a, b, c, neg, o.rounding)
+	if ok {
+		var r T
+
+		if r, ok = fromMagnitude[T](m, neg); ok {
+			return r, nil
+		}
+	}
+
+	args := make([]float64, len(inputs))
+	for i, x := range inputs {
+		args[i] = float64(x)
+	}
+
+	return overflow[T](op, neg, o, args...)
+}
+
+// overflow returns the bound of the integer type T on the side given by neg if o saturates,
+// and an *Error wrapping ErrOverflow otherwise.
+func overflow[T constraints.Integer | constraints.Float](op string, neg bool, o options, inputs ...float64) (T, error) {
+	if !o.saturate {
+		return 0, newError(op, ErrOverflow, inputs...)
+	}
+
+	lo, hi := bounds[T]()
+	if neg {
+		return lo, nil
+	}
+
+	return hi, nil
+}
+
+// bounds returns the smallest and the largest value of the integer type T.
+func bounds[T constraints.Integer | constraints.Float]() (T, T) {
+	// Set low bits until the next one wraps around, at the sign bit of signed types or past
+	// the top bit of unsigned ones.
+	hi := T(1)
+	for hi*2+1 > hi {
+		hi = hi*2 + 1
+	}
+
+	if zero := T(0); zero-1 > 0 {
+		return 0, hi
+	}
+
+	return -hi - 1, hi
+}
+
+// mulDiv returns the magnitude of a*b/c rounded with mode, where neg is the sign of the
+// result. It reports false if the magnitude does not fit in 64 bits.
+func mulDiv(a, b, c uint64, neg bool, mode RoundingMode) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= c {
+		return 0, false
+	}
+
+	q, r := bits.Div64(hi, lo, c)
+	if r == 0 {
+		return q, true
+	}
+
+	// Compare r with the other part of the divisor to avoid overflowing 2*r.
+	var away bool
+
+	switch mode {
+	case HalfAwayFromZero:
+		away = r >= c-r
+	case HalfUp:
+		away = r > c-r || (r == c-r && !neg)
+	case HalfEven:
+		away = r > c-r || (r == c-r && q&1 == 1)
+	case Floor:
+		away = neg
+	case Ceil:
+		away = !neg
+	case Truncate:
+		away = false
+	}
+
+	if !away {
+		return q, true
+	}
+
+	if q == math.MaxUint64 {
+		return 0, false
+	}
+
+	return q + 1, true
+}
+
+// magnitude returns the absolute value of the integer x and whether x is negative.
+func magnitude[T constraints.Integer | constraints.Float](x T) (uint64, bool) {
+	if x < 0 {
+		// Negate x+1 so that the minimum value of signed types does not overflow.
+		return uint64(-(x + 1)) + 1, true
+	}
+
+	return uint64(x), false
+}
+
+// fromMagnitude returns the value of the integer type T with magnitude m, negated if neg. It
+// reports false if the value does not fit in T.
+func fromMagnitude[T constraints.Integer | constraints.Float](m uint64, neg bool) (T, bool) {
+	if m == 0 {
+		return 0, true
+	}
+
+	if !neg {
+		r := T(m)
+
+		return r, r > 0 && uint64(r) == m
+	}
+
+	r := -T(m)
+	if r >= 0 {
+		return 0, false
+	}
+
+	got, _ := magnitude(r)
+
+	return r, got == m
+}
@@ -0,0 +1,361 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestPercentInt(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		percent int64
+		value   int64
+		opts    []percent.Option
+	}
+
+	type want struct {
+		value int64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "typical case",
+			in:   in{percent: 25, value: 200, opts: nil},
+			want: want{value: 50, err: nil},
+		},
+		{
+			name: "exact above 2^53",
+			in:   in{percent: 50, value: 1<<53 + 2, opts: nil},
+			want: want{value: 1<<52 + 1, err: nil},
+		},
+		{
+			name: "largest value",
+			in:   in{percent: 100, value: math.MaxInt64, opts: nil},
+			want: want{value: math.MaxInt64, err: nil},
+		},
+		{
+			name: "smallest value",
+			in:   in{percent: 50, value: math.MinInt64, opts: nil},
+			want: want{value: math.MinInt64 / 2, err: nil},
+		},
+		{
+			name: "tie rounds away from zero by default",
+			in:   in{percent: 50, value: -5, opts: nil},
+			want: want{value: -3, err: nil},
+		},
+		{
+			name: "tie rounds up",
+			in:   in{percent: 50, value: -5, opts: []percent.Option{percent.WithRounding(percent.HalfUp)}},
+			want: want{value: -2, err: nil},
+		},
+		{
+			name: "tie rounds to even",
+			in:   in{percent: 50, value: 5, opts: []percent.Option{percent.WithRounding(percent.HalfEven)}},
+			want: want{value: 2, err: nil},
+		},
+		{
+			name: "floor",
+			in:   in{percent: 10, value: -15, opts: []percent.Option{percent.WithRounding(percent.Floor)}},
+			want: want{value: -2, err: nil},
+		},
+		{
+			name: "ceil",
+			in:   in{percent: 10, value: 11, opts: []percent.Option{percent.WithRounding(percent.Ceil)}},
+			want: want{value: 2, err: nil},
+		},
+		{
+			name: "truncate",
+			in:   in{percent: 99, value: 99, opts: []percent.Option{percent.WithRounding(percent.Truncate)}},
+			want: want{value: 98, err: nil},
+		},
+		{
+			name: "negative percent",
+			in:   in{percent: -1, value: 100, opts: nil},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "percent over 100",
+			in:   in{percent: 101, value: 100, opts: nil},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.PercentInt(tt.in.percent, tt.in.value, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("PercentInt() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("PercentInt(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestRemainInt(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		percent uint64
+		value   uint64
+	}
+
+	type want struct {
+		value uint64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "typical case",
+			in:   in{percent: 25, value: 200},
+			want: want{value: 150, err: nil},
+		},
+		{
+			name: "largest value",
+			in:   in{percent: 1, value: math.MaxUint64},
+			want: want{value: 18262276632972456099, err: nil},
+		},
+		{
+			name: "percent over 100",
+			in:   in{percent: 101, value: 100},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.RemainInt(tt.in.percent, tt.in.value)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("RemainInt() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("RemainInt(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestOfInt(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		part  int8
+		total int8
+	}
+
+	type want struct {
+		value int8
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "typical case",
+			in:   in{part: 1, total: 3},
+			want: want{value: 33, err: nil},
+		},
+		{
+			name: "part equals total",
+			in:   in{part: 127, total: 127},
+			want: want{value: 100, err: nil},
+		},
+		{
+			name: "negative values",
+			in:   in{part: -100, total: -80},
+			want: want{value: 125, err: nil},
+		},
+		{
+			name: "result does not fit",
+			in:   in{part: -128, total: 1},
+			want: want{value: 0, err: percent.ErrOverflow},
+		},
+		{
+			name: "zero total",
+			in:   in{part: 1, total: 0},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "part greater than total",
+			in:   in{part: 2, total: 1},
+			want: want{value: 0, err: percent.ErrPartGreaterThanTotal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.OfInt(tt.in.part, tt.in.total)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("OfInt() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("OfInt(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestChangeInt(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		oldValue int64
+		newValue int64
+		opts     []percent.Option
+	}
+
+	type want struct {
+		value int64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "increase",
+			in:   in{oldValue: 25, newValue: 100, opts: nil},
+			want: want{value: 300, err: nil},
+		},
+		{
+			name: "decrease",
+			in:   in{oldValue: 3, newValue: 2, opts: nil},
+			want: want{value: -33, err: nil},
+		},
+		{
+			name: "negative old value",
+			in:   in{oldValue: -50, newValue: 50, opts: nil},
+			want: want{value: 200, err: nil},
+		},
+		{
+			name: "opposite extremes",
+			in:   in{oldValue: math.MinInt64, newValue: math.MaxInt64, opts: nil},
+			want: want{value: 200, err: nil},
+		},
+		{
+			name: "result does not fit",
+			in:   in{oldValue: 1, newValue: math.MaxInt64, opts: nil},
+			want: want{value: 0, err: percent.ErrOverflow},
+		},
+		{
+			name: "zero old value",
+			in:   in{oldValue: 0, newValue: 100, opts: nil},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "zero old value ignores the zero baseline",
+			in: in{
+				oldValue: 0, newValue: 100,
+				opts: []percent.Option{percent.WithZeroBaseline(percent.ZeroBaselineInf)},
+			},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "zero old value ignores the epsilon",
+			in:   in{oldValue: 0, newValue: 100, opts: []percent.Option{percent.WithEpsilon(1)}},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.ChangeInt(tt.in.oldValue, tt.in.newValue, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("ChangeInt() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("ChangeInt(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func FuzzPercentInt(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		percent int64
+		value   int64
+	}{
+		{50, 1<<53 + 1},      // beyond float64 precision
+		{100, math.MaxInt64}, // largest value
+		{33, math.MinInt64},  // smallest value
+		{0, 12345},           // zero percent
+		{-1, 100},            // negative percent (should error)
+		{101, 100},           // over 100 percent (should error)
+	}
+	for _, tc := range testcases {
+		f.Add(tc.percent, tc.value) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, pct, value int64) {
+		// Arrange
+		hundred := big.NewInt(100)
+
+		// Act
+		got, err := percent.PercentInt(pct, value, percent.WithRounding(percent.Truncate))
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: If percent is out of range [0, 100], should return ErrOutOfRange
+		// Property 3: If no error, result equals value*percent/100 truncated toward zero
+
+		if pct < 0 || pct > 100 {
+			if !errors.Is(err, percent.ErrOutOfRange) {
+				t.Errorf("PercentInt(%v, %v) error = %v, want ErrOutOfRange", pct, value, err)
+			}
+
+			return
+		}
+		if err != nil {
+			t.Fatalf("PercentInt(%v, %v) returned unexpected error: %v", pct, value, err)
+		}
+		want := new(big.Int).Mul(big.NewInt(value), big.NewInt(pct))
+		want.Quo(want, hundred)
+		if want.Int64() != got {
+			t.Errorf("PercentInt(%v, %v) = %v, want %v", pct, value, got, want)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// groupSize is the number of digits of a digit group.
+const groupSize = 3
+
+// Locale describes how percentages are written in a language and region.
+type Locale struct {
+	// Tag is the BCP 47 language tag, e.g. "de-DE".
+	Tag string
+	// Decimal is the decimal separator.
+	Decimal string
+	// Group is the digit grouping separator, or empty for no grouping.
+	Group string
+	// Secondary is the number of digits of the groups ahead of the last group of three, such
+	// as 2 for "1,00,000" in en-IN, or zero if they also have three digits.
+	Secondary int
+	// Pattern places the number, marked by '#', relative to the percent sign, e.g. "# %".
+	Pattern string
+}
+
+// Locales returns the built-in locales.
+func Locales() []Locale {
+	const (
+		nbsp  = "\u00a0"
+		nnbsp = "\u202f"
+	)
+
+	return []Locale{
+		{Tag: "en-US", Decimal: ".", Group: ",", Secondary: 0, Pattern: "#%"},
+		{Tag: "en-GB", Decimal: ".", Group: ",", Secondary: 0, Pattern: "#%"},
+		{Tag: "en-IN", Decimal: ".", Group: ",", Secondary: 2, Pattern: "#%"},
+		{Tag: "de-DE", Decimal: ",", Group: ".", Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "de-AT", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "de-CH", Decimal: ".", Group: "’", Secondary: 0, Pattern: "#%"},
+		{Tag: "fr-FR", Decimal: ",", Group: nnbsp, Secondary: 0, Pattern: "#" + nnbsp + "%"},
+		{Tag: "fr-CH", Decimal: ",", Group: nnbsp, Secondary: 0, Pattern: "#%"},
+		{Tag: "es-ES", Decimal: ",", Group: ".", Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "es-MX", Decimal: ".", Group: ",", Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "it-IT", Decimal: ",", Group: ".", Secondary: 0, Pattern: "#%"},
+		{Tag: "pt-BR", Decimal: ",", Group: ".", Secondary: 0, Pattern: "#%"},
+		{Tag: "pt-PT", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#%"},
+		{Tag: "nl-NL", Decimal: ",", Group: ".", Secondary: 0, Pattern: "#%"},
+		{Tag: "da-DK", Decimal: ",", Group: ".", Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "sv-SE", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "nb-NO", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "fi-FI", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "pl-PL", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#%"},
+		{Tag: "cs-CZ", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "ru-RU", Decimal: ",", Group: nbsp, Secondary: 0, Pattern: "#" + nbsp + "%"},
+		{Tag: "tr-TR", Decimal: ",", Group: ".", Secondary: 0, Pattern: "%#"},
+		{Tag: "ja-JP", Decimal: ".", Group: ",", Secondary: 0, Pattern: "#%"},
+		{Tag: "ko-KR", Decimal: ".", Group: ",", Secondary: 0, Pattern: "#%"},
+		{Tag: "zh-CN", Decimal: ".", Group: ",", Secondary: 0, Pattern: "#%"},
+		{Tag: "ar-EG", Decimal: "\u066b", Group: "\u066c", Secondary: 0, Pattern: "#\u066a"},
+	}
+}
+
+// LookupLocale returns the built-in locale for tag. Tags are matched case-insensitively, with
+// '_' accepted in place of '-'. A tag without a region, such as "de", matches the first locale
+// of that language. The second result reports whether a locale was found.
+func LookupLocale(tag string) (Locale, bool) {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	locales := Locales()
+
+	for _, l := range locales {
+		if strings.EqualFold(l.Tag, tag) {
+			return l, true
+		}
+	}
+
+	for _, l := range locales {
+		if lang, _, _ := strings.Cut(l.Tag, "-"); strings.EqualFold(lang, tag) {
+			return l, true
+		}
+	}
+
+	return Locale{}, false
+}
+
+// Format returns p written according to l, with prec digits after the decimal separator. A
+// negative prec yields the fewest digits that represent p exactly.
+func (l Locale) Format(p Percentage, prec int) string {
+	x := float64(p)
+
+	sign := ""
+	if x < 0 {
+		sign, x = "-", -x
+	}
+
+	num := strconv.FormatFloat(x, 'f', prec, 64)
+	whole, frac, _ := strings.Cut(num, ".")
+
+	var b strings.Builder
+
+	b.WriteString(l.group(whole))
+
+	if frac != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+
+	return sign + strings.Replace(l.Pattern, "#", b.String(), 1)
+}
+
+// Parse returns the Percentage represented by s, written according to l. It accepts the
+// notations of Parse, with the decimal and grouping separators of l.
+func (l Locale) Parse(s string) (Percentage, error) {
+	v, u, err := scan(s, notation{decimal: l.Decimal, group: l.Group, secondary: l.secondary()})
+	if err != nil {
+		return 0, err
+	}
+
+	p := toPercent(v, u, unitPercent)
+	if err := checkRange("Parse", p, resource.PercentMin, resource.PercentMax, p); err != nil {
+		return 0, err
+	}
+
+	return Percentage(p), nil
+}
+
+// group inserts the grouping separator of l into the digits of whole.
+func (l Locale) group(whole string) string {
+	if l.Group == "" || len(whole) <= groupSize {
+		return whole
+	}
+
+	size := l.secondary()
+	head, last := whole[:len(whole)-groupSize], whole[len(whole)-groupSize:]
+
+	var b strings.Builder
+
+	first := len(head) % size
+	if first > 0 {
+		b.WriteString(head[:first])
+	}
+
+	for i := first; i < len(head); i += size {
+		if i > 0 {
+			b.WriteString(l.Group)
+		}
+
+		b.WriteString(head[i : i+size])
+	}
+
+	b.WriteString(l.Group)
+	b.WriteString(last)
+
+	return b.String()
+}
+
+// secondary returns the number of digits of the groups of l ahead of the last one.
+func (l Locale) secondary() int {
+	if l.Secondary > 0 {
+		return l.Secondary
+	}
+
+	return groupSize
+}
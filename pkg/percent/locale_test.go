@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestLookupLocale(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		tag string
+		ok  bool
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{name: "exact tag", in: "de-DE", want: want{tag: "de-DE", ok: true}},
+		{name: "case insensitive", in: "TR-tr", want: want{tag: "tr-TR", ok: true}},
+		{name: "underscore", in: "fr_FR", want: want{tag: "fr-FR", ok: true}},
+		{name: "language only", in: "de", want: want{tag: "de-DE", ok: true}},
+		{name: "unknown", in: "xx-XX", want: want{tag: "", ok: false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, ok := percent.LookupLocale(tt.in)
+
+			// Assert
+			if got.Tag != tt.want.tag || ok != tt.want.ok {
+				t.Errorf("LookupLocale(%q) = %q, %v, want %q, %v", tt.in, got.Tag, ok, tt.want.tag, tt.want.ok)
+			}
+		})
+	}
+}
+
+func TestLocaleFormat(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		tag   string
+		value percent.Percentage
+		prec  int
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want string
+	}{
+		{name: "en-US", in: in{tag: "en-US", value: 12.5, prec: -1}, want: "12.5%"},
+		{name: "de-DE", in: in{tag: "de-DE", value: 12.5, prec: -1}, want: "12,5\u00a0%"},
+		{name: "tr-TR", in: in{tag: "tr-TR", value: 12.5, prec: -1}, want: "%12,5"},
+		{name: "fr-FR grouping", in: in{tag: "fr-FR", value: 1234.5, prec: 2}, want: "1\u202f234,50\u202f%"},
+		{name: "en-US grouping", in: in{tag: "en-US", value: 1234567, prec: 0}, want: "1,234,567%"},
+		{name: "en-IN grouping", in: in{tag: "en-IN", value: 1234567, prec: 0}, want: "12,34,567%"},
+		{name: "en-IN lakh", in: in{tag: "en-IN", value: 100000, prec: 1}, want: "1,00,000.0%"},
+		{name: "en-IN thousand", in: in{tag: "en-IN", value: 1000, prec: 0}, want: "1,000%"},
+		{name: "de-CH grouping", in: in{tag: "de-CH", value: 12345.678, prec: 1}, want: "12’345.7%"},
+		{name: "tr-TR negative", in: in{tag: "tr-TR", value: -5, prec: 0}, want: "-%5"},
+		{name: "ar-EG", in: in{tag: "ar-EG", value: 12.5, prec: -1}, want: "12٫5٪"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			l, ok := percent.LookupLocale(tt.in.tag)
+			if !ok {
+				t.Fatalf("LookupLocale(%q) not found", tt.in.tag)
+			}
+
+			// Act
+			got := l.Format(tt.in.value, tt.in.prec)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("Format(%v, %d) = %q, want %q", tt.in.value, tt.in.prec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		tag string
+		s   string
+	}
+
+	type want struct {
+		value percent.Percentage
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "de-DE typed space", in: in{tag: "de-DE", s: "12,5 %"}, want: want{value: 12.5, err: nil}},
+		{name: "tr-TR prefix sign", in: in{tag: "tr-TR", s: "%12,5"}, want: want{value: 12.5, err: nil}},
+		{name: "ru-RU typed space", in: in{tag: "ru-RU", s: "0,5 %"}, want: want{value: 0.5, err: nil}},
+		{name: "en-US short group", in: in{tag: "en-US", s: "0,5%"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "en-US grouped", in: in{tag: "en-US", s: "0,050%"}, want: want{value: 50, err: nil}},
+		{name: "en-US 1.5", in: in{tag: "en-US", s: "1.5%"}, want: want{value: 1.5, err: nil}},
+		{name: "en-US 1.50", in: in{tag: "en-US", s: "1.50%"}, want: want{value: 1.5, err: nil}},
+		{name: "en-US 12.3456", in: in{tag: "en-US", s: "12.3456%"}, want: want{value: 12.3456, err: nil}},
+		{
+			name: "en-US group after decimal",
+			in:   in{tag: "en-US", s: "1.234,5%"},
+			want: want{value: 0, err: percent.ErrSyntax},
+		},
+		{name: "de-DE 1.5", in: in{tag: "de-DE", s: "1.5%"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "de-DE 1.50", in: in{tag: "de-DE", s: "1.50%"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "de-DE 12.3456", in: in{tag: "de-DE", s: "12.3456%"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "de-DE long first group", in: in{tag: "de-DE", s: "1234.567 %"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "en-IN lakh", in: in{tag: "en-IN", s: "1,00,000 ppm"}, want: want{value: 10, err: nil}},
+		{name: "en-IN thousands", in: in{tag: "en-IN", s: "100,000 ppm"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "de-DE grouped decimal", in: in{tag: "de-DE", s: "0.050,5 %"}, want: want{value: 50.5, err: nil}},
+		{name: "de-DE grouped out of range", in: in{tag: "de-DE", s: "1.500 %"}, want: want{value: 0, err: percent.ErrOutOfRange}},
+		{name: "de-DE trailing group", in: in{tag: "de-DE", s: "1. %"}, want: want{value: 0, err: percent.ErrSyntax}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			l, _ := percent.LookupLocale(tt.in.tag)
+
+			// Act
+			got, err := l.Parse(tt.in.s)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Parse(%q) error = %v, want err %v", tt.in.s, err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("Parse(%q) = %v, want %v", tt.in.s, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestLocaleRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	values := []percent.Percentage{0, 0.07, 5, 12.5, 33.333, 99.99, 100}
+
+	for _, l := range percent.Locales() {
+		t.Run(l.Tag, func(t *testing.T) {
+			for _, p := range values {
+				// Arrange
+				s := l.Format(p, -1)
+
+				// Act
+				got, err := l.Parse(s)
+
+				// Assert
+				if err != nil || got != p {
+					t.Errorf("Parse(Format(%v)) = Parse(%q) = %v, %v", float64(p), s, got, err)
+				}
+			}
+		})
+	}
+}
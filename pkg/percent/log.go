@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// logPrecision is the default number of decimal places in percent of logged values.
+const logPrecision = 2
+
+// LogValue implements slog.LogValuer. It returns p as a string with two decimal places,
+// e.g. "12.50%".
+func (p Percentage) LogValue() slog.Value {
+	return p.wire().logValue(float64(p), logPrecision, HalfAwayFromZero)
+}
+
+// LogValue implements slog.LogValuer. It returns r as a string with four decimal places,
+// the resolution of a Percentage, e.g. "0.1250".
+func (r Ratio) LogValue() slog.Value {
+	return r.wire().logValue(float64(r), logPrecision, HalfAwayFromZero)
+}
+
+// LogValue implements slog.LogValuer. It returns b as a string of whole basis points, the
+// resolution of a Percentage, e.g. "1250bp".
+func (b BasisPoints) LogValue() slog.Value {
+	return b.wire().logValue(float64(b), logPrecision, HalfAwayFromZero)
+}
+
+// LogValue implements slog.LogValuer. It returns m as a string with one decimal place, the
+// resolution of a Percentage, e.g. "125.0‰".
+func (m PerMille) LogValue() slog.Value {
+	return m.wire().logValue(float64(m), logPrecision, HalfAwayFromZero)
+}
+
+// LogValue implements slog.LogValuer. It returns m as a string of whole parts per million,
+// e.g. "125000ppm".
+func (m PPM) LogValue() slog.Value {
+	return m.wire().logValue(float64(m), logPrecision, HalfAwayFromZero)
+}
+
+// points is a difference of percentages in percentage points.
+type points float64
+
+// LogValue implements slog.LogValuer. It returns p as a string with two decimal places,
+// e.g. "-2.50pp".
+func (p points) LogValue() slog.Value {
+	return p.wire().logValue(float64(p), logPrecision, HalfAwayFromZero)
+}
+
+// wire returns the encoding of points.
+func (points) wire() wire {
+	return wire{
+		name: "points", arg: "points", symbol: pointsSymbol,
+		bare: unitPercent, exp: 0, hi: resource.PercentMax,
+	}
+}
+
+// PercentAttr returns an Attr for percent, such as a result of Of or Change, that is logged
+// like a Percentage, e.g. "33.33%" rather than 33.333333333333336.
+func PercentAttr(key string, percent float64) slog.Attr {
+	return slog.Any(key, Percentage(percent))
+}
+
+// RatioAttr returns an Attr for ratio that is logged like a Ratio, e.g. "0.3333".
+func RatioAttr(key string, ratio float64) slog.Attr {
+	return slog.Any(key, Ratio(ratio))
+}
+
+// PointsAttr returns an Attr for a difference in percentage points, such as a result of
+// PointChange, e.g. "-2.50pp".
+func PointsAttr(key string, pp float64) slog.Attr {
+	return slog.Any(key, points(pp))
+}
+
+// LogHandler is a slog.Handler that renders the percent values of records, such as the
+// percent types and the attributes of PercentAttr, RatioAttr and PointsAttr, with its own
+// precision and rounding mode before passing them on to another handler. Values are rendered
+// as strings with a unit symbol, so text and JSON handlers show them alike.
+type LogHandler struct {
+	handler slog.Handler
+	prec    int
+	mode    RoundingMode
+}
+
+// NewLogHandler returns a LogHandler that passes records on to h. WithPrecision selects the
+// number of decimal places in percent, two by default, which is scaled for the other units
+// down to whole basis points or parts per million, and WithRounding the rounding mode.
+func NewLogHandler(h slog.Handler, opts ...Option) *LogHandler {
+	o := newOptions(opts)
+
+	prec := o.precision
+	if prec < 0 {
+		prec = logPrecision
+	}
+
+	return &LogHandler{handler: h, prec: prec, mode: o.rounding}
+}
+
+// Enabled implements slog.Handler.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.attr(a))
+
+		return true
+	})
+
+	return h.handler.Handle(ctx, out)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{handler: h.handler.WithAttrs(h.attrs(attrs)), prec: h.prec, mode: h.mode}
+}
+
+// WithGroup implements slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{handler: h.handler.WithGroup(name), prec: h.prec, mode: h.mode}
+}
+
+// attr returns a with percent values rendered by h, including those in groups.
+func (h *LogHandler) attr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindLogValuer:
+		if v, ok := h.value(a.Value.LogValuer()); ok {
+			a.Value = v
+		}
+	case slog.KindGroup:
+		a.Value = slog.GroupValue(h.attrs(a.Value.Group())...)
+	case slog.KindAny, slog.KindBool, slog.KindDuration, slog.KindFloat64, slog.KindInt64,
+		slog.KindString, slog.KindTime, slog.KindUint64:
+	}
+
+	return a
+}
+
+// attrs returns attrs with percent values rendered by h.
+func (h *LogHandler) attrs(attrs []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		out[i] = h.attr(a)
+	}
+
+	return out
+}
+
+// value returns v rendered by h, and false if v is not a percent value.
+func (h *LogHandler) value(v slog.LogValuer) (slog.Value, bool) {
+	switch v := v.(type) {
+	case Percentage:
+		return v.wire().logValue(float64(v), h.prec, h.mode), true
+	case Ratio:
+		return v.wire().logValue(float64(v), h.prec, h.mode), true
+	case BasisPoints:
+		return v.wire().logValue(float64(v), h.prec, h.mode), true
+	case PerMille:
+		return v.wire().logValue(float64(v), h.prec, h.mode), true
+	case PPM:
+		return v.wire().logValue(float64(v), h.prec, h.mode), true
+	case points:
+		return v.wire().logValue(float64(v), h.prec, h.mode), true
+	}
+
+	return slog.Value{}, false
+}
+
+// logValue returns x, in the unit of w, as a string with prec decimal places in percent,
+// rounded using mode, followed by the unit symbol.
+func (w wire) logValue(x float64, prec int, mode RoundingMode) slog.Value {
+	prec = max(prec-w.exp, 0)
+
+	return slog.StringValue(strconv.FormatFloat(Round(x, prec, mode), 'f', prec, 64) + w.symbol)
+}
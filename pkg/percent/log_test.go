@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   slog.LogValuer
+		want string
+	}{
+		{name: "percentage", in: percent.Percentage(100.0 / 3), want: "33.33%"},
+		{name: "percentage pads", in: percent.Percentage(12.5), want: "12.50%"},
+		{name: "ratio", in: percent.Ratio(0.125), want: "0.1250"},
+		{name: "basis points", in: percent.BasisPoints(1250.4), want: "1250bp"},
+		{name: "per mille", in: percent.PerMille(125), want: "125.0‰"},
+		{name: "ppm", in: percent.PPM(125000), want: "125000ppm"},
+		{name: "negative", in: percent.Percentage(-2.005), want: "-2.01%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got := tt.in.LogValue()
+
+			// Assert
+			if got.Kind() != slog.KindString || got.String() != tt.want {
+				t.Errorf("LogValue() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		json bool
+		opts []percent.Option
+		log  func(*slog.Logger)
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want string
+	}{
+		{
+			name: "text",
+			in: in{json: false, opts: nil, log: func(l *slog.Logger) {
+				l.Info("share", percent.PercentAttr("share", 100.0/3), percent.RatioAttr("ratio", 1.0/3))
+			}},
+			want: "level=INFO msg=share share=33.33% ratio=0.3333\n",
+		},
+		{
+			name: "json",
+			in: in{json: true, opts: nil, log: func(l *slog.Logger) {
+				l.Info("change", percent.PointsAttr("delta", -2.5), "rate", percent.Percentage(5))
+			}},
+			want: `{"level":"INFO","msg":"change","delta":"-2.50pp","rate":"5.00%"}` + "\n",
+		},
+		{
+			name: "precision and rounding",
+			in: in{
+				json: false,
+				opts: []percent.Option{percent.WithPrecision(1), percent.WithRounding(percent.Floor)},
+				log: func(l *slog.Logger) {
+					l.Info("units", "p", percent.Percentage(12.38), "r", percent.Ratio(0.12345), "bp", percent.BasisPoints(1239))
+				},
+			},
+			want: "level=INFO msg=units p=12.3% r=0.123 bp=1239bp\n",
+		},
+		{
+			name: "groups and attributes",
+			in: in{json: true, opts: []percent.Option{percent.WithPrecision(0)}, log: func(l *slog.Logger) {
+				l.With(percent.PercentAttr("base", 12.5)).WithGroup("g").Info("grouped",
+					slog.Group("inner", percent.PercentAttr("p", 40.5)), "raw", 0.5)
+			}},
+			want: `{"level":"INFO","msg":"grouped","base":"13%","g":{"inner":{"p":"41%"},"raw":0.5}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+
+			opts := &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return a
+			}}
+
+			var h slog.Handler = slog.NewTextHandler(&buf, opts)
+			if tt.in.json {
+				h = slog.NewJSONHandler(&buf, opts)
+			}
+
+			// Act
+			tt.in.log(slog.New(percent.NewLogHandler(h, tt.in.opts...)))
+
+			// Assert
+			if got := buf.String(); got != tt.want {
+				t.Errorf("log output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
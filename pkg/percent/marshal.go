@@ -0,0 +1,384 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// The percent types implement json.Marshaler, encoding.TextMarshaler and
+// encoding.BinaryMarshaler along with their Unmarshaler counterparts. The type of a value
+// selects its wire representation:
+//
+//   - JSON writes a number in the unit of the type, so Percentage 25 is written as 25 and the
+//     same proportion as a Ratio as 0.25. Wrap a value in Quoted to write it as a string such
+//     as "25%" instead.
+//   - Text is the String form, e.g. "25%", "0.25" or "2500bp".
+//   - Binary is the IEEE 754 bits of the value in big-endian order.
+//
+// Decoding accepts a JSON number or string, and any notation understood by Parse, where a
+// number without a unit is read in the unit of the type. Decoded values are validated as by
+// the New functions: an *Error wrapping ErrNotFinite or ErrOutOfRange is returned for values
+// that are not finite or not within the range of the type, and the receiver is left unchanged.
+
+// binarySize is the length of the binary encoding of the percent types.
+const binarySize = 8
+
+// Unit is the set of percent types.
+type Unit interface {
+	Percentage | Ratio | BasisPoints | PerMille | PPM
+
+	encoding.TextMarshaler
+	wire() wire
+}
+
+// Quoted wraps a percent value so that it is written to JSON as a string in its String form,
+// e.g. "25%" rather than 25. Decoding accepts the same input as the wrapped type.
+type Quoted[T Unit] struct {
+	Value T
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q Quoted[T]) MarshalJSON() ([]byte, error) {
+	text, err := q.Value.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (q *Quoted[T]) UnmarshalJSON(data []byte) error {
+	u, _ := any(&q.Value).(json.Unmarshaler)
+
+	return u.UnmarshalJSON(data)
+}
+
+// String returns the String form of the wrapped value.
+func (q Quoted[T]) String() string {
+	text, _ := q.Value.MarshalText()
+
+	return string(text)
+}
+
+// wire describes how a percent type is encoded.
+type wire struct {
+	// name is the name of the type, used in errors.
+	name string
+	// arg names a value of the type in usage messages.
+	arg string
+	// symbol is the unit symbol appended by String.
+	symbol string
+	// bare is the unit of a number without a unit.
+	bare unit
+	// exp is the decimal exponent that converts percent to the type.
+	exp int
+	// hi is the largest valid value.
+	hi float64
+}
+
+// MarshalJSON implements json.Marshaler. It writes p as a number in percent, e.g. 25.
+func (p Percentage) MarshalJSON() ([]byte, error) {
+	return p.wire().marshalJSON(float64(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Percentage) UnmarshalJSON(data []byte) error {
+	return p.wire().unmarshalJSON(data, (*float64)(p))
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes p as String does, e.g. "25%".
+func (p Percentage) MarshalText() ([]byte, error) {
+	return p.wire().marshalText(float64(p), p.String())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Percentage) UnmarshalText(text []byte) error {
+	return p.wire().unmarshalText(text, (*float64)(p))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (p Percentage) MarshalBinary() ([]byte, error) {
+	return p.wire().marshalBinary(float64(p))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *Percentage) UnmarshalBinary(data []byte) error {
+	return p.wire().unmarshalBinary(data, (*float64)(p))
+}
+
+// wire returns the encoding of Percentage.
+func (Percentage) wire() wire {
+	return wire{
+		name: "Percentage", arg: "percentage", symbol: percentSymbol,
+		bare: unitPercent, exp: 0, hi: resource.PercentMax,
+	}
+}
+
+// MarshalJSON implements json.Marshaler. It writes r as a number, e.g. 0.25.
+func (r Ratio) MarshalJSON() ([]byte, error) {
+	return r.wire().marshalJSON(float64(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Ratio) UnmarshalJSON(data []byte) error {
+	return r.wire().unmarshalJSON(data, (*float64)(r))
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes r as String does, e.g. "0.25".
+func (r Ratio) MarshalText() ([]byte, error) {
+	return r.wire().marshalText(float64(r), r.String())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Ratio) UnmarshalText(text []byte) error {
+	return r.wire().unmarshalText(text, (*float64)(r))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (r Ratio) MarshalBinary() ([]byte, error) {
+	return r.wire().marshalBinary(float64(r))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *Ratio) UnmarshalBinary(data []byte) error {
+	return r.wire().unmarshalBinary(data, (*float64)(r))
+}
+
+// wire returns the encoding of Ratio.
+func (Ratio) wire() wire {
+	return wire{
+		name: "Ratio", arg: "ratio", symbol: "",
+		bare: unitNone, exp: -2, hi: resource.RatioMax,
+	}
+}
+
+// MarshalJSON implements json.Marshaler. It writes b as a number of basis points, e.g. 2500.
+func (b BasisPoints) MarshalJSON() ([]byte, error) {
+	return b.wire().marshalJSON(float64(b))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *BasisPoints) UnmarshalJSON(data []byte) error {
+	return b.wire().unmarshalJSON(data, (*float64)(b))
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes b as String does, e.g. "2500bp".
+func (b BasisPoints) MarshalText() ([]byte, error) {
+	return b.wire().marshalText(float64(b), b.String())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *BasisPoints) UnmarshalText(text []byte) error {
+	return b.wire().unmarshalText(text, (*float64)(b))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (b BasisPoints) MarshalBinary() ([]byte, error) {
+	return b.wire().marshalBinary(float64(b))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *BasisPoints) UnmarshalBinary(data []byte) error {
+	return b.wire().unmarshalBinary(data, (*float64)(b))
+}
+
+// wire returns the encoding of BasisPoints.
+func (BasisPoints) wire() wire {
+	return wire{
+		name: "BasisPoints", arg: "basis-points", symbol: basisPointsSymbol,
+		bare: unitBasisPoints, exp: 2, hi: resource.BasisPointsMax,
+	}
+}
+
+// MarshalJSON implements json.Marshaler. It writes m as a number in per mille, e.g. 250.
+func (m PerMille) MarshalJSON() ([]byte, error) {
+	return m.wire().marshalJSON(float64(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *PerMille) UnmarshalJSON(data []byte) error {
+	return m.wire().unmarshalJSON(data, (*float64)(m))
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes m as String does, e.g. "250‰".
+func (m PerMille) MarshalText() ([]byte, error) {
+	return m.wire().marshalText(float64(m), m.String())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *PerMille) UnmarshalText(text []byte) error {
+	return m.wire().unmarshalText(text, (*float64)(m))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (m PerMille) MarshalBinary() ([]byte, error) {
+	return m.wire().marshalBinary(float64(m))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *PerMille) UnmarshalBinary(data []byte) error {
+	return m.wire().unmarshalBinary(data, (*float64)(m))
+}
+
+// wire returns the encoding of PerMille.
+func (PerMille) wire() wire {
+	return wire{
+		name: "PerMille", arg: "per-mille", symbol: perMilleSymbol,
+		bare: unitPerMille, exp: 1, hi: resource.PerMilleMax,
+	}
+}
+
+// MarshalJSON implements json.Marshaler. It writes m as a number of parts per million, e.g.
+// 250000.
+func (m PPM) MarshalJSON() ([]byte, error) {
+	return m.wire().marshalJSON(float64(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *PPM) UnmarshalJSON(data []byte) error {
+	return m.wire().unmarshalJSON(data, (*float64)(m))
+}
+
+// MarshalText implements encoding.TextMarshaler. It writes m as String does, e.g.
+// "250000ppm".
+func (m PPM) MarshalText() ([]byte, error) {
+	return m.wire().marshalText(float64(m), m.String())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *PPM) UnmarshalText(text []byte) error {
+	return m.wire().unmarshalText(text, (*float64)(m))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (m PPM) MarshalBinary() ([]byte, error) {
+	return m.wire().marshalBinary(float64(m))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *PPM) UnmarshalBinary(data []byte) error {
+	return m.wire().unmarshalBinary(data, (*float64)(m))
+}
+
+// wire returns the encoding of PPM.
+func (PPM) wire() wire {
+	return wire{
+		name: "PPM", arg: "ppm", symbol: ppmSymbol,
+		bare: unitPPM, exp: 4, hi: resource.PPMMax,
+	}
+}
+
+// marshalJSON returns x as a JSON number.
+func (w wire) marshalJSON(x float64) ([]byte, error) {
+	if err := checkFinite(w.name+".MarshalJSON", x); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(x)
+}
+
+// unmarshalJSON decodes data, a JSON number or string, into dst. A JSON null leaves dst
+// unchanged.
+func (w wire) unmarshalJSON(data []byte, dst *float64) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		return w.unmarshalText([]byte(s), dst)
+	}
+
+	var x float64
+	if err := json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+
+	return w.store(w.name+".UnmarshalJSON", x, dst)
+}
+
+// marshalText returns s, the String form of x.
+func (w wire) marshalText(x float64, s string) ([]byte, error) {
+	if err := checkFinite(w.name+".MarshalText", x); err != nil {
+		return nil, err
+	}
+
+	return []byte(s), nil
+}
+
+// unmarshalText parses text into dst. A number without a unit is read in the unit of w.
+func (w wire) unmarshalText(text []byte, dst *float64) error {
+	return w.parse(w.name+".UnmarshalText", string(text), w, dst)
+}
+
+// marshalBinary returns the IEEE 754 bits of x in big-endian order.
+func (w wire) marshalBinary(x float64) ([]byte, error) {
+	if err := checkFinite(w.name+".MarshalBinary", x); err != nil {
+		return nil, err
+	}
+
+	return binary.BigEndian.AppendUint64(make([]byte, 0, binarySize), math.Float64bits(x)), nil
+}
+
+// unmarshalBinary decodes data, as written by marshalBinary, into dst.
+func (w wire) unmarshalBinary(data []byte, dst *float64) error {
+	if len(data) != binarySize {
+		return &SyntaxError{Input: string(data), Offset: len(data), Msg: resource.InvalidLengthMessage}
+	}
+
+	return w.store(w.name+".UnmarshalBinary", math.Float64frombits(binary.BigEndian.Uint64(data)), dst)
+}
+
+// parse parses s for op into dst. A number without a unit is read in the unit of from.
+func (w wire) parse(op, s string, from wire, dst *float64) error {
+	v, u, err := scan(s, notation{})
+	if err != nil {
+		return err
+	}
+
+	return w.store(op, w.convert(v, u, from), dst)
+}
+
+// convert returns v, written in unit u, in the unit of w. A number without a unit is in the
+// unit of from. Numbers already in the unit of w are returned as they are, so that they read
+// back exactly.
+func (w wire) convert(v float64, u unit, from wire) float64 {
+	switch {
+	case u == unitNone || u == from.bare:
+		if from.exp == w.exp {
+			return v
+		}
+
+		return shift(v, w.exp-from.exp)
+	case u == w.bare:
+		return v
+	}
+
+	return shift(toPercent(v, u, from.bare), w.exp)
+}
+
+// store validates x for op and assigns it to dst.
+func (w wire) store(op string, x float64, dst *float64) error {
+	if err := checkFinite(op, x); err != nil {
+		return err
+	}
+
+	if err := checkRange(op, x, 0, w.hi, x); err != nil {
+		return err
+	}
+
+	*dst = x
+
+	return nil
+}
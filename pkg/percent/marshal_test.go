@@ -0,0 +1,462 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		value any
+	}
+
+	type want struct {
+		json string
+		err  error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "percentage as number in percent",
+			in:   in{value: percent.Percentage(25)},
+			want: want{json: `25`, err: nil},
+		},
+		{
+			name: "ratio as number",
+			in:   in{value: percent.Ratio(0.25)},
+			want: want{json: `0.25`, err: nil},
+		},
+		{
+			name: "basis points",
+			in:   in{value: percent.BasisPoints(2500)},
+			want: want{json: `2500`, err: nil},
+		},
+		{
+			name: "per mille",
+			in:   in{value: percent.PerMille(250)},
+			want: want{json: `250`, err: nil},
+		},
+		{
+			name: "ppm",
+			in:   in{value: percent.PPM(250000)},
+			want: want{json: `250000`, err: nil},
+		},
+		{
+			name: "quoted percentage",
+			in:   in{value: percent.Quoted[percent.Percentage]{Value: 12.5}},
+			want: want{json: `"12.5%"`, err: nil},
+		},
+		{
+			name: "quoted basis points",
+			in:   in{value: percent.Quoted[percent.BasisPoints]{Value: 1250}},
+			want: want{json: `"1250bp"`, err: nil},
+		},
+		{
+			name: "struct field",
+			in: in{value: struct {
+				Rate  percent.Percentage                 `json:"rate"`
+				Share percent.Ratio                      `json:"share"`
+				Label percent.Quoted[percent.Percentage] `json:"label"`
+			}{Rate: 5, Share: 0.05, Label: percent.Quoted[percent.Percentage]{Value: 5}}},
+			want: want{json: `{"rate":5,"share":0.05,"label":"5%"}`, err: nil},
+		},
+		{
+			name: "not a number",
+			in:   in{value: percent.Percentage(math.NaN())},
+			want: want{json: ``, err: percent.ErrNotFinite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := json.Marshal(tt.in.value)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Marshal() error = %v, want err %v", err, tt.want.err)
+			}
+			if string(got) != tt.want.json {
+				t.Errorf("Marshal(%v) = %s, want %s", tt.in.value, got, tt.want.json)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		data string
+	}
+
+	type want struct {
+		value percent.Percentage
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "number",
+			in:   in{data: `12.5`},
+			want: want{value: 12.5, err: nil},
+		},
+		{
+			name: "string with percent sign",
+			in:   in{data: `"12.5%"`},
+			want: want{value: 12.5, err: nil},
+		},
+		{
+			name: "string without unit",
+			in:   in{data: `"12.5"`},
+			want: want{value: 12.5, err: nil},
+		},
+		{
+			name: "string in basis points",
+			in:   in{data: `"1250bp"`},
+			want: want{value: 12.5, err: nil},
+		},
+		{
+			name: "null leaves the value unchanged",
+			in:   in{data: `null`},
+			want: want{value: 42, err: nil},
+		},
+		{
+			name: "above 100",
+			in:   in{data: `100.5`},
+			want: want{value: 42, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "negative string",
+			in:   in{data: `"-1%"`},
+			want: want{value: 42, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "malformed string",
+			in:   in{data: `"abc"`},
+			want: want{value: 42, err: percent.ErrSyntax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			got := percent.Percentage(42)
+
+			// Act
+			err := json.Unmarshal([]byte(tt.in.data), &got)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Unmarshal() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.in.data, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestUnmarshalUnits(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call func() (any, error)
+	}
+
+	type want struct {
+		value any
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ratio from number",
+			in:   in{call: func() (any, error) { return unmarshal[percent.Ratio](`0.25`) }},
+			want: want{value: percent.Ratio(0.25), err: nil},
+		},
+		{
+			name: "ratio from percent string",
+			in:   in{call: func() (any, error) { return unmarshal[percent.Ratio](`"25%"`) }},
+			want: want{value: percent.Ratio(0.25), err: nil},
+		},
+		{
+			name: "ratio above one",
+			in:   in{call: func() (any, error) { return unmarshal[percent.Ratio](`25`) }},
+			want: want{value: percent.Ratio(0), err: percent.ErrOutOfRange},
+		},
+		{
+			name: "basis points from percent string",
+			in:   in{call: func() (any, error) { return unmarshal[percent.BasisPoints](`"12.5%"`) }},
+			want: want{value: percent.BasisPoints(1250), err: nil},
+		},
+		{
+			name: "per mille from bare string",
+			in:   in{call: func() (any, error) { return unmarshal[percent.PerMille](`"3"`) }},
+			want: want{value: percent.PerMille(3), err: nil},
+		},
+		{
+			name: "ppm above range",
+			in:   in{call: func() (any, error) { return unmarshal[percent.PPM](`1000001`) }},
+			want: want{value: percent.PPM(0), err: percent.ErrOutOfRange},
+		},
+		{
+			name: "quoted from string",
+			in: in{call: func() (any, error) {
+				q, err := unmarshal[percent.Quoted[percent.Percentage]](`"25%"`)
+
+				return q.Value, err
+			}},
+			want: want{value: percent.Percentage(25), err: nil},
+		},
+		{
+			name: "quoted from number",
+			in: in{call: func() (any, error) {
+				q, err := unmarshal[percent.Quoted[percent.Ratio]](`0.5`)
+
+				return q.Value, err
+			}},
+			want: want{value: percent.Ratio(0.5), err: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call()
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("got %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		text string
+	}
+
+	type want struct {
+		value percent.BasisPoints
+		text  string
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "basis points",
+			in:   in{text: "1250bp"},
+			want: want{value: 1250, text: "1250bp", err: nil},
+		},
+		{
+			name: "bare number in basis points",
+			in:   in{text: "1250"},
+			want: want{value: 1250, text: "1250bp", err: nil},
+		},
+		{
+			name: "percent",
+			in:   in{text: "0.07%"},
+			want: want{value: 7, text: "7bp", err: nil},
+		},
+		{
+			name: "out of range",
+			in:   in{text: "10001bp"},
+			want: want{value: 0, text: "0bp", err: percent.ErrOutOfRange},
+		},
+		{
+			name: "empty",
+			in:   in{text: ""},
+			want: want{value: 0, text: "0bp", err: percent.ErrSyntax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var got percent.BasisPoints
+
+			// Act
+			err := got.UnmarshalText([]byte(tt.in.text))
+			text, _ := got.MarshalText()
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("UnmarshalText() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("UnmarshalText(%q) = %v, want %v", tt.in.text, got, tt.want.value)
+			}
+			if string(text) != tt.want.text {
+				t.Errorf("MarshalText() = %q, want %q", text, tt.want.text)
+			}
+		})
+	}
+}
+
+func TestBinary(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		data []byte
+	}
+
+	type want struct {
+		value percent.Percentage
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "twenty five",
+			in:   in{data: []byte{0x40, 0x39, 0, 0, 0, 0, 0, 0}},
+			want: want{value: 25, err: nil},
+		},
+		{
+			name: "short",
+			in:   in{data: []byte{0x40, 0x39}},
+			want: want{value: 0, err: percent.ErrSyntax},
+		},
+		{
+			name: "out of range",
+			in:   in{data: []byte{0x40, 0x59, 0x40, 0, 0, 0, 0, 0}},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "not a number",
+			in:   in{data: []byte{0x7f, 0xf8, 0, 0, 0, 0, 0, 1}},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var got percent.Percentage
+
+			// Act
+			err := got.UnmarshalBinary(tt.in.data)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("UnmarshalBinary() error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("UnmarshalBinary(%x) = %v, want %v", tt.in.data, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func FuzzMarshal(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []float64{
+		25.0,        // typical case
+		0.0,         // lower bound
+		100.0,       // upper bound
+		100.0 / 3,   // repeating decimal
+		5e-324,      // smallest denormal
+		-1.0,        // out of range
+		math.Inf(1), // infinity
+		math.NaN(),  // not a number
+		1e21,        // exponent in JSON
+		0.07,        // inexact binary fraction
+	}
+	for _, tc := range testcases {
+		f.Add(tc) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, x float64) {
+		// Arrange
+		p := percent.Percentage(x)
+		_, valid := percent.NewPercentage(x)
+
+		// Act
+		data, errJSON := json.Marshal(p)
+		text, errText := p.MarshalText()
+		bin, errBin := p.MarshalBinary()
+
+		var fromJSON, fromText, fromBin percent.Percentage
+		errJSON = errors.Join(errJSON, json.Unmarshal(data, &fromJSON))
+		errText = errors.Join(errText, fromText.UnmarshalText(text))
+		errBin = errors.Join(errBin, fromBin.UnmarshalBinary(bin))
+
+		// Assert
+		// Property 1: Functions should never panic
+		// Property 2: Valid values round-trip exactly in every representation
+		// Property 3: Invalid values are rejected by every representation
+
+		for _, tc := range []struct {
+			name string
+			got  percent.Percentage
+			err  error
+		}{
+			{"JSON", fromJSON, errJSON},
+			{"Text", fromText, errText},
+			{"Binary", fromBin, errBin},
+		} {
+			if valid != nil {
+				if tc.err == nil {
+					t.Errorf("%s round trip of %v returned no error", tc.name, x)
+				}
+
+				continue
+			}
+
+			if tc.err != nil {
+				t.Fatalf("%s round trip of %v returned unexpected error: %v", tc.name, x, tc.err)
+			}
+			if tc.got != p {
+				t.Errorf("%s round trip of %v = %v", tc.name, x, tc.got)
+			}
+		}
+	})
+}
+
+// unmarshal decodes the JSON data into a new T.
+func unmarshal[T any](data string) (T, error) {
+	var v T
+	err := json.Unmarshal([]byte(data), &v)
+
+	return v, err
+}
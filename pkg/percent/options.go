@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+// Option configures an operation such as Percent or Of.
+type Option func(*options)
+
+// options holds the settings applied by Option values.
+type options struct {
+	precision int
+	rounding  RoundingMode
+	nonFinite bool
+	saturate  bool
+	policy    Policy
+	zero      ZeroBaseline
+	epsilon   float64
+	workers   int
+}
+
+// newOptions returns the settings of opts applied to the defaults. It is cheap enough to be
+// inlined, and calls without options do not allocate.
+func newOptions(opts []Option) options {
+	if len(opts) == 0 {
+		return options{precision: -1, rounding: HalfAwayFromZero}
+	}
+
+	return applyOptions(opts)
+}
+
+// applyOptions returns the settings of opts applied to the defaults.
+func applyOptions(opts []Option) options {
+	o := new(options)
+	*o = newOptions(nil)
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return *o
+}
+
+// WithPrecision rounds results to prec decimal places. A negative prec, the default, leaves
+// results unrounded.
+func WithPrecision(prec int) Option {
+	return func(o *options) {
+		o.precision = prec
+	}
+}
+
+// WithRounding selects the rounding mode used with WithPrecision. The default is
+// HalfAwayFromZero.
+func WithRounding(mode RoundingMode) Option {
+	return func(o *options) {
+		o.rounding = mode
+	}
+}
+
+// AllowNonFinite lets NaN and infinite inputs through and returns NaN and infinite results as
+// they are. By default such inputs and results are rejected with ErrNotFinite.
+func AllowNonFinite() Option {
+	return func(o *options) {
+		o.nonFinite = true
+	}
+}
+
+// WithSaturation clamps integer results that do not fit in their type to the nearest bound
+// of the type. By default such results are rejected with ErrOverflow.
+func WithSaturation() Option {
+	return func(o *options) {
+		o.saturate = true
+	}
+}
+
+// finite returns an *Error wrapping ErrNotFinite if one of inputs is NaN or infinite, unless o
+// allows it.
+func (o *options) finite(op string, inputs ...float64) error {
+	if o.nonFinite {
+		return nil
+	}
+
+	return checkFinite(op, inputs...)
+}
+
+// result returns x rounded according to o. It returns an *Error wrapping ErrNotFinite if x is
+// NaN or infinite, unless o allows it.
+func (o *options) result(op string, x float64, inputs ...float64) (float64, error) {
+	if !o.nonFinite && !finite(x) {
+		return 0, newError(op, ErrNotFinite, inputs...)
+	}
+
+	return o.round(x), nil
+}
+
+// round returns x rounded according to o.
+func (o *options) round(x float64) float64 {
+	if o.precision < 0 {
+		return x
+	}
+
+	return Round(x, o.precision, o.rounding)
+}
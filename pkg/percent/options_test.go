@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call func(opts ...percent.Option) (float64, error)
+		opts []percent.Option
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "of without options",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Of(1, 3, opts...) },
+				opts: nil,
+			},
+			want: want{value: 33.33333333333333, err: nil},
+		},
+		{
+			name: "of with precision",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Of(1, 3, opts...) },
+				opts: []percent.Option{percent.WithPrecision(2)},
+			},
+			want: want{value: 33.33, err: nil},
+		},
+		{
+			name: "of with half even",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Of(1, 8, opts...) },
+				opts: []percent.Option{percent.WithPrecision(1), percent.WithRounding(percent.HalfEven)},
+			},
+			want: want{value: 12.5, err: nil},
+		},
+		{
+			name: "percent with ceil",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Percent(7, 0.1, opts...) },
+				opts: []percent.Option{percent.WithPrecision(2), percent.WithRounding(percent.Ceil)},
+			},
+			want: want{value: 0.01, err: nil},
+		},
+		{
+			name: "change with floor",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Change(3, 4, opts...) },
+				opts: []percent.Option{percent.WithPrecision(0), percent.WithRounding(percent.Floor)},
+			},
+			want: want{value: 33, err: nil},
+		},
+		{
+			name: "change negative with half up",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Change(8, 7.9, opts...) },
+				opts: []percent.Option{percent.WithPrecision(2), percent.WithRounding(percent.HalfUp)},
+			},
+			want: want{value: -1.25, err: nil},
+		},
+		{
+			name: "remain with truncate",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Remain(33, 10.0/3, opts...) },
+				opts: []percent.Option{percent.WithPrecision(3), percent.WithRounding(percent.Truncate)},
+			},
+			want: want{value: 2.233, err: nil},
+		},
+		{
+			name: "from ratio with precision",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.FromRatio(0.123456, opts...) },
+				opts: []percent.Option{percent.WithPrecision(1)},
+			},
+			want: want{value: 12.3, err: nil},
+		},
+		{
+			name: "to ratio with precision",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.ToRatio(33.333, opts...) },
+				opts: []percent.Option{percent.WithPrecision(2), percent.WithRounding(percent.HalfAwayFromZero)},
+			},
+			want: want{value: 0.33, err: nil},
+		},
+		{
+			name: "errors are unaffected",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Of(1, 0, opts...) },
+				opts: []percent.Option{percent.WithPrecision(2)},
+			},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "infinite input rejected",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Of(1, math.Inf(1), opts...) },
+				opts: nil,
+			},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+		{
+			name: "infinite input allowed",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Of(1, math.Inf(1), opts...) },
+				opts: []percent.Option{percent.AllowNonFinite()},
+			},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "overflowing result rejected",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Change(-1e-300, 1e300, opts...) },
+				opts: nil,
+			},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+		{
+			name: "overflowing result allowed",
+			in: in{
+				call: func(opts ...percent.Option) (float64, error) { return percent.Change(-1e-300, 1e300, opts...) },
+				opts: []percent.Option{percent.AllowNonFinite(), percent.WithPrecision(2)},
+			},
+			want: want{value: math.Inf(1), err: nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call(tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("got %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+// chunkSize is the number of elements of a unit of work of the parallel operations, and of a
+// partial sum. As the chunks do not depend on the number of workers, neither do the results.
+const chunkSize = 1 << 13
+
+// WithWorkers sets the number of goroutines of the parallel operations such as
+// PercentSliceParallel. Zero or a negative n, the default, selects runtime.GOMAXPROCS(0).
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// PercentSliceParallel is like PercentSlice, with the elements split into chunks that are
+// processed by the workers set with WithWorkers. The results and the failed elements are the
+// same as those of PercentSlice for any number of workers.
+//
+// If ctx is done before every chunk is processed, PercentSliceParallel returns the error of
+// ctx, and the elements of dst that were not processed are left unchanged.
+func PercentSliceParallel[T constraints.Integer | constraints.Float](
+	ctx context.Context, dst []float64, percents, values []T, opts ...Option,
+) error {
+	if len(percents) != len(dst) || len(values) != len(dst) {
+		return lengthError("PercentSliceParallel", len(dst), len(percents), len(values))
+	}
+
+	o := newOptions(opts)
+
+	return o.parallel(ctx, len(dst), func(lo, hi int) *SliceError {
+		return percentRange(&o, "PercentSliceParallel", dst, percents, values, lo, hi)
+	})
+}
+
+// OfSliceParallel is like OfSlice, with the elements split into chunks that are processed by
+// the workers set with WithWorkers. The results and the failed elements are the same as those
+// of OfSlice for any number of workers.
+//
+// If ctx is done before every chunk is processed, OfSliceParallel returns the error of ctx,
+// and the elements of dst that were not processed are left unchanged.
+func OfSliceParallel[T constraints.Integer | constraints.Float](
+	ctx context.Context, dst []float64, parts, totals []T, opts ...Option,
+) error {
+	if len(parts) != len(dst) || len(totals) != len(dst) {
+		return lengthError("OfSliceParallel", len(dst), len(parts), len(totals))
+	}
+
+	o := newOptions(opts)
+
+	return o.parallel(ctx, len(dst), func(lo, hi int) *SliceError {
+		return ofRange(&o, "OfSliceParallel", dst, parts, totals, lo, hi)
+	})
+}
+
+// ChangeSeriesParallel is like ChangeSeries, with the changes split into chunks that are
+// processed by the workers set with WithWorkers. The results and the failed elements are the
+// same as those of ChangeSeries for any number of workers.
+//
+// If ctx is done before every chunk is processed, ChangeSeriesParallel returns the error of
+// ctx, and the elements of dst that were not processed are left unchanged.
+func ChangeSeriesParallel[T constraints.Integer | constraints.Float](
+	ctx context.Context, dst []float64, series []T, opts ...Option,
+) error {
+	if !seriesLength(len(dst), len(series)) {
+		return lengthError("ChangeSeriesParallel", len(dst), len(series))
+	}
+
+	o := newOptions(opts)
+
+	return o.parallel(ctx, len(dst), func(lo, hi int) *SliceError {
+		return changeRange(&o, "ChangeSeriesParallel", dst, series, lo, hi)
+	})
+}
+
+// SharesOfParallel is like SharesOf, with both the sum of the parts and the shares split into
+// chunks that are processed by the workers set with WithWorkers. The results and the failed
+// elements are the same as those of SharesOf for any number of workers.
+//
+// If ctx is done before every chunk is processed, SharesOfParallel returns the error of ctx,
+// and the elements of dst that were not processed are left unchanged.
+func SharesOfParallel[T constraints.Integer | constraints.Float](
+	ctx context.Context, dst []float64, parts []T, opts ...Option,
+) error {
+	if len(parts) != len(dst) {
+		return lengthError("SharesOfParallel", len(dst), len(parts))
+	}
+
+	o := newOptions(opts)
+
+	partials := make([]float64, chunks(len(parts)))
+	if err := o.forChunks(ctx, len(parts), func(c, lo, hi int) {
+		partials[c] = sumRange(parts, lo, hi)
+	}); err != nil {
+		return err
+	}
+
+	var sum kahan
+	for _, p := range partials {
+		sum.add(p)
+	}
+
+	total := sum.value()
+
+	return o.parallel(ctx, len(dst), func(lo, hi int) *SliceError {
+		return sharesRange(&o, "SharesOfParallel", dst, parts, total, lo, hi)
+	})
+}
+
+// Summary describes the elements of a slice, such as the shares of SharesOf or the changes of
+// ChangeSeries.
+type Summary struct {
+	// Count is the number of summarized elements.
+	Count int
+	// Sum is the sum of the elements, with compensation for rounding errors.
+	Sum float64
+	// Min and Max are the smallest and the largest element, or zero for no elements.
+	Min, Max float64
+	// MinIndex and MaxIndex are the first indices of Min and Max, or -1 for no elements.
+	MinIndex, MaxIndex int
+}
+
+// Summarize returns the Summary of x, computed by the workers set with WithWorkers; the other
+// options are ignored. The elements are summed with Kahan-Babuška-Neumaier compensation in
+// chunks of fixed size whose sums are compensated again, so Sum is accurate even for
+// hundreds of millions of elements and does not depend on the number of workers.
+//
+// Summarize returns a *SliceError wrapping ErrNotFinite if elements are NaN or infinite,
+// together with the Summary of the other elements, and the error of ctx if it is done before
+// every chunk is processed.
+func Summarize[T constraints.Integer | constraints.Float](ctx context.Context, x []T, opts ...Option) (Summary, error) {
+	o := newOptions(opts)
+
+	partials := make([]Summary, chunks(len(x)))
+	errs := make([]*SliceError, len(partials))
+
+	if err := o.forChunks(ctx, len(x), func(c, lo, hi int) {
+		partials[c], errs[c] = summarizeRange(x, lo, hi)
+	}); err != nil {
+		return Summary{Count: 0, Sum: 0, Min: 0, Max: 0, MinIndex: -1, MaxIndex: -1}, err
+	}
+
+	s := Summary{Count: 0, Sum: 0, Min: 0, Max: 0, MinIndex: -1, MaxIndex: -1}
+
+	var sum kahan
+
+	for _, p := range partials {
+		if p.Count == 0 {
+			continue
+		}
+
+		s.Count += p.Count
+		sum.add(p.Sum)
+
+		if s.MinIndex < 0 || p.Min < s.Min {
+			s.Min, s.MinIndex = p.Min, p.MinIndex
+		}
+
+		if s.MaxIndex < 0 || p.Max > s.Max {
+			s.Max, s.MaxIndex = p.Max, p.MaxIndex
+		}
+	}
+
+	s.Sum = sum.value()
+
+	return s, mergeSliceErrors(errs)
+}
+
+// summarizeRange returns the Summary of the elements [lo, hi) of x, and the non-finite ones.
+func summarizeRange[T constraints.Integer | constraints.Float](x []T, lo, hi int) (Summary, *SliceError) {
+	s := Summary{Count: 0, Sum: 0, Min: math.Inf(1), Max: math.Inf(-1), MinIndex: -1, MaxIndex: -1}
+
+	var (
+		sum kahan
+		se  *SliceError
+	)
+
+	for i, v := range x[lo:hi] {
+		f := float64(v)
+		if !finite(f) {
+			se = se.add("Summarize", lo+i, len(x), newError("Summarize", ErrNotFinite, f))
+
+			continue
+		}
+
+		s.Count++
+		sum.add(f)
+
+		if f < s.Min {
+			s.Min, s.MinIndex = f, lo+i
+		}
+
+		if f > s.Max {
+			s.Max, s.MaxIndex = f, lo+i
+		}
+	}
+
+	s.Sum = sum.value()
+
+	return s, se
+}
+
+// sumRange returns the compensated sum of the elements [lo, hi) of x.
+func sumRange[T constraints.Integer | constraints.Float](x []T, lo, hi int) float64 {
+	var sum kahan
+	for _, v := range x[lo:hi] {
+		sum.add(float64(v))
+	}
+
+	return sum.value()
+}
+
+// kahan is a sum with Kahan-Babuška-Neumaier compensation, which keeps the rounding errors of
+// the additions and adds them back in the end.
+type kahan struct {
+	sum, c float64
+}
+
+// add adds x to k.
+func (k *kahan) add(x float64) {
+	t := k.sum + x
+	if math.Abs(k.sum) >= math.Abs(x) {
+		k.c += (k.sum - t) + x
+	} else {
+		k.c += (x - t) + k.sum
+	}
+
+	k.sum = t
+}
+
+// value returns the compensated sum.
+func (k *kahan) value() float64 {
+	return k.sum + k.c
+}
+
+// chunks returns the number of chunks of n elements.
+func chunks(n int) int {
+	return (n + chunkSize - 1) / chunkSize
+}
+
+// parallel calls f with the bounds of each chunk of n elements on the workers of o and returns
+// the failed elements of all chunks in order, or the error of ctx if it is done before every
+// chunk is processed.
+func (o *options) parallel(ctx context.Context, n int, f func(lo, hi int) *SliceError) error {
+	errs := make([]*SliceError, chunks(n))
+	if err := o.forChunks(ctx, n, func(c, lo, hi int) {
+		errs[c] = f(lo, hi)
+	}); err != nil {
+		return err
+	}
+
+	return mergeSliceErrors(errs)
+}
+
+// forChunks calls f with the index and the bounds of each chunk of n elements on the workers
+// of o, which take the chunks in turn. It returns the error of ctx if it is done before every
+// chunk is processed.
+func (o *options) forChunks(ctx context.Context, n int, f func(c, lo, hi int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	count := chunks(n)
+
+	workers := o.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		next, done atomic.Int64
+		wg         sync.WaitGroup
+	)
+
+	for range min(workers, count) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				c := int(next.Add(1) - 1)
+				if c >= count {
+					return
+				}
+
+				f(c, c*chunkSize, min((c+1)*chunkSize, n))
+				done.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if int(done.Load()) < count {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// mergeSliceErrors returns the failed elements of errs, which are in order of their indices,
+// as one *SliceError, or nil if no element failed.
+func mergeSliceErrors(errs []*SliceError) error {
+	var se *SliceError
+
+	for _, e := range errs {
+		switch {
+		case e == nil:
+		case se == nil:
+			se = e
+		default:
+			se.Indices = append(se.Indices, e.Indices...)
+			se.Errs = append(se.Errs, e.Errs...)
+		}
+	}
+
+	return se.err()
+}
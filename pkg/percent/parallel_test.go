@@ -0,0 +1,380 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// parallelValues returns n values with a pattern that spans several chunks, including zeros and
+// values above 100 at which some operations fail.
+func parallelValues(n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64((i*7919)%211) - 5
+	}
+
+	return x
+}
+
+// nonFiniteValues returns the values of parallelValues with NaN and infinities spread over
+// the chunks.
+func nonFiniteValues(n int) []float64 {
+	x := parallelValues(n)
+	for i := 1; i < n; i += 4999 {
+		x[i] = math.NaN()
+
+		if i+1 < n {
+			x[i+1] = math.Inf(1 - i%2*2)
+		}
+	}
+
+	return x
+}
+
+// sameFailures reports whether err and other report the same failed elements, or wrap the
+// same sentinel error if they are not *SliceError values.
+func sameFailures(err, other error) bool {
+	var (
+		se, so *percent.SliceError
+		pe, po *percent.Error
+	)
+
+	switch {
+	case errors.As(err, &se) && errors.As(other, &so):
+		return slices.Equal(se.Indices, so.Indices) && slices.Equal(se.Errs, so.Errs)
+	case errors.As(err, &pe) && errors.As(other, &po):
+		return pe.Err == po.Err
+	}
+
+	return err == other
+}
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+
+	const n = 40_003
+
+	x, y, z := parallelValues(n), parallelValues(n + 1)[1:], nonFiniteValues(n)
+
+	type op func(ctx context.Context, dst []float64, opts ...percent.Option) error
+
+	tests := []struct {
+		name     string
+		len      int
+		err      error
+		serial   op
+		parallel op
+	}{
+		{
+			name: "percent slice",
+			len:  n,
+			err:  percent.ErrOutOfRange,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.PercentSlice(dst, x, y, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.PercentSliceParallel(ctx, dst, x, y, opts...)
+			},
+		},
+		{
+			name: "of slice",
+			len:  n,
+			err:  percent.ErrPartGreaterThanTotal,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.OfSlice(dst, x, y, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.OfSliceParallel(ctx, dst, x, y, opts...)
+			},
+		},
+		{
+			name: "change series",
+			len:  n - 1,
+			err:  percent.ErrDivideByZero,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.ChangeSeries(dst, x, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.ChangeSeriesParallel(ctx, dst, x, opts...)
+			},
+		},
+		{
+			name: "shares of",
+			len:  n,
+			err:  nil,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.SharesOf(dst, y, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.SharesOfParallel(ctx, dst, y, opts...)
+			},
+		},
+		{
+			name: "percent slice not finite",
+			len:  n,
+			err:  percent.ErrNotFinite,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.PercentSlice(dst, z, y, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.PercentSliceParallel(ctx, dst, z, y, opts...)
+			},
+		},
+		{
+			name: "of slice not finite",
+			len:  n,
+			err:  percent.ErrNotFinite,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.OfSlice(dst, z, y, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.OfSliceParallel(ctx, dst, z, y, opts...)
+			},
+		},
+		{
+			name: "change series not finite",
+			len:  n - 1,
+			err:  percent.ErrNotFinite,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.ChangeSeries(dst, z, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.ChangeSeriesParallel(ctx, dst, z, opts...)
+			},
+		},
+		{
+			name: "length mismatch",
+			len:  n + 1,
+			err:  percent.ErrLengthMismatch,
+			serial: func(_ context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.OfSlice(dst, x, y, opts...)
+			},
+			parallel: func(ctx context.Context, dst []float64, opts ...percent.Option) error {
+				return percent.OfSliceParallel(ctx, dst, x, y, opts...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			want := make([]float64, tt.len)
+			wantErr := tt.serial(context.Background(), want, percent.WithPrecision(2))
+			if !errors.Is(wantErr, tt.err) {
+				t.Fatalf("serial error = %v, want err %v", wantErr, tt.err)
+			}
+
+			for _, workers := range []int{0, 1, 3, 16} {
+				got := make([]float64, tt.len)
+
+				// Act
+				err := tt.parallel(context.Background(), got, percent.WithPrecision(2), percent.WithWorkers(workers))
+
+				// Assert
+				if !slices.Equal(want, got) {
+					t.Errorf("%d workers: results differ from the serial operation", workers)
+				}
+
+				if !sameFailures(wantErr, err) {
+					t.Errorf("%d workers: error = %v, want %v", workers, err, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestParallelCanceled(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	x := parallelValues(10)
+	dst := make([]float64, len(x))
+
+	// Act
+	errs := []error{
+		percent.PercentSliceParallel(ctx, dst, x, x),
+		percent.OfSliceParallel(ctx, dst, x, x),
+		percent.ChangeSeriesParallel(ctx, dst[1:], x),
+		percent.SharesOfParallel(ctx, dst, x),
+	}
+	_, err := percent.Summarize(ctx, x)
+	errs = append(errs, err)
+
+	// Assert
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("operation %d error = %v, want %v", i, err, context.Canceled)
+		}
+	}
+
+	if diff := cmp.Diff(make([]float64, len(x)), dst); diff != "" {
+		t.Errorf("canceled operations wrote results (-want +got):\n%s", diff)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		summary percent.Summary
+		indices []int
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		in   []float64
+		want want
+	}{
+		{
+			name: "values",
+			in:   []float64{25, -50, 12.5, 80},
+			want: want{
+				summary: percent.Summary{Count: 4, Sum: 67.5, Min: -50, Max: 80, MinIndex: 1, MaxIndex: 3},
+				indices: nil,
+				err:     nil,
+			},
+		},
+		{
+			name: "ties",
+			in:   []float64{3, 1, 3, 1},
+			want: want{
+				summary: percent.Summary{Count: 4, Sum: 8, Min: 1, Max: 3, MinIndex: 1, MaxIndex: 0},
+				indices: nil,
+				err:     nil,
+			},
+		},
+		{
+			name: "compensated sum",
+			in:   []float64{1e16, 1, -1e16, 0.5, 0.25},
+			want: want{
+				summary: percent.Summary{Count: 5, Sum: 1.75, Min: -1e16, Max: 1e16, MinIndex: 2, MaxIndex: 0},
+				indices: nil,
+				err:     nil,
+			},
+		},
+		{
+			name: "empty",
+			in:   nil,
+			want: want{
+				summary: percent.Summary{Count: 0, Sum: 0, Min: 0, Max: 0, MinIndex: -1, MaxIndex: -1},
+				indices: nil,
+				err:     nil,
+			},
+		},
+		{
+			name: "not finite",
+			in:   []float64{math.NaN(), 2, math.Inf(-1), 4},
+			want: want{
+				summary: percent.Summary{Count: 2, Sum: 6, Min: 2, Max: 4, MinIndex: 1, MaxIndex: 3},
+				indices: []int{0, 2},
+				err:     percent.ErrNotFinite,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			got, err := percent.Summarize(context.Background(), tt.in)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Summarize(%v) error = %v, want err %v", tt.in, err, tt.want.err)
+			}
+
+			if diff := cmp.Diff(tt.want.indices, sliceIndices(err)); diff != "" {
+				t.Errorf("Summarize(%v) indices mismatch (-want +got):\n%s", tt.in, diff)
+			}
+
+			if diff := cmp.Diff(tt.want.summary, got); diff != "" {
+				t.Errorf("Summarize(%v) mismatch (-want +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestSummarizeWorkers(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	x := make([]float64, 1_000_003)
+	for i := range x {
+		x[i] = 0.1 * float64(i%1000)
+	}
+
+	want, err := percent.Summarize(context.Background(), x, percent.WithWorkers(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{0, 2, 7, 64} {
+		// Act
+		got, err := percent.Summarize(context.Background(), x, percent.WithWorkers(workers))
+
+		// Assert
+		if err != nil || got != want {
+			t.Errorf("Summarize() with %d workers = %+v, %v, want %+v", workers, got, err, want)
+		}
+	}
+
+	// The exact sum is 0.1 * 1000 * 999 / 2 per 1000 elements, plus the last 3 elements.
+	if exact := 0.1*999*1000/2*1000 + 0.1*3; math.Abs(want.Sum-exact) > 1e-6 {
+		t.Errorf("Summarize() Sum = %v, want %v", want.Sum, exact)
+	}
+}
+
+// BenchmarkPercentSliceParallel compares PercentSlice with PercentSliceParallel on one worker
+// and on all processors.
+func BenchmarkPercentSliceParallel(b *testing.B) {
+	const n = 1 << 22
+
+	percents, values, dst := benchValues(n), benchValues(n), make([]float64, n)
+
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			benchError = percent.PercentSlice(dst, percents, values)
+		}
+	})
+
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				benchError = percent.PercentSliceParallel(context.Background(), dst, percents, values,
+					percent.WithWorkers(workers))
+			}
+		})
+	}
+}
+
+// BenchmarkSummarize measures Summarize on one worker and on all processors.
+func BenchmarkSummarize(b *testing.B) {
+	x := benchValues(1 << 22)
+
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				_, benchError = percent.Summarize(context.Background(), x, percent.WithWorkers(workers))
+			}
+		})
+	}
+}
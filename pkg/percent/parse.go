@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// SyntaxError describes text that could not be parsed as a percentage. It wraps ErrSyntax.
+type SyntaxError struct {
+	// Input is the text that was parsed.
+	Input string
+	// Offset is the byte offset in Input at which parsing failed.
+	Offset int
+	// Msg describes the problem.
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf(resource.SyntaxErrorFormat, ErrSyntax, e.Input, e.Offset, e.Msg)
+}
+
+// Unwrap returns ErrSyntax.
+func (e *SyntaxError) Unwrap() error {
+	return ErrSyntax
+}
+
+// unit is the notation a parsed number was written in.
+type unit int
+
+const (
+	unitNone unit = iota
+	unitPercent
+	unitPerMille
+	unitBasisPoints
+	unitPPM
+)
+
+// Parse returns the Percentage represented by s.
+//
+// The number may be followed by a unit: a percent sign ("25%", "12.5 %", and the full-width
+// "％" or Arabic "٪" signs), "pct" or "percent", a per mille sign ("3‰"), a per ten thousand
+// sign ("25‱"), "bp" or "bps" for basis points, or "ppm". A percent sign may also precede the
+// number ("%25"). A number without a unit is read as a percentage. Full-width and Arabic-Indic
+// digits are accepted. Use Locale.Parse for numbers with locale-specific separators.
+//
+// Parse returns a *SyntaxError if s is malformed, and an *Error wrapping ErrOutOfRange if the
+// value is not within [0, 100].
+func Parse(s string) (Percentage, error) {
+	v, u, err := scan(s, notation{})
+	if err != nil {
+		return 0, err
+	}
+
+	p := toPercent(v, u, unitPercent)
+	if err := checkRange("Parse", p, resource.PercentMin, resource.PercentMax, p); err != nil {
+		return 0, err
+	}
+
+	return Percentage(p), nil
+}
+
+// ParseRatio returns the Ratio represented by s. It accepts the same notations as Parse, except
+// that a number without a unit is read as a ratio, so "0.25" and "25%" are equivalent.
+//
+// ParseRatio returns a *SyntaxError if s is malformed, and an *Error wrapping ErrOutOfRange if
+// the value is not within [0, 1].
+func ParseRatio(s string) (Ratio, error) {
+	v, u, err := scan(s, notation{})
+	if err != nil {
+		return 0, err
+	}
+
+	r := shift(toPercent(v, u, unitNone), -2)
+	if err := checkRange("ParseRatio", r, resource.RatioMin, resource.RatioMax, r); err != nil {
+		return 0, err
+	}
+
+	return Ratio(r), nil
+}
+
+// toPercent converts v, written in unit u, to percent. Values without a unit are read as bare.
+func toPercent(v float64, u, bare unit) float64 {
+	if u == unitNone {
+		u = bare
+	}
+
+	switch u {
+	case unitPercent:
+		return v
+	case unitPerMille:
+		return shift(v, -1)
+	case unitBasisPoints:
+		return shift(v, -2)
+	case unitPPM:
+		return shift(v, -4)
+	case unitNone:
+		return shift(v, 2)
+	}
+
+	return v
+}
+
+// notation describes the separators accepted by scan. The zero value accepts the decimal
+// points of isDecimalPoint and no digit grouping. The groups ahead of the last group of
+// groupSize digits have secondary digits.
+type notation struct {
+	decimal   string
+	group     string
+	secondary int
+}
+
+// decimalAt returns the length of the decimal separator at the start of s, or 0 if there is
+// none.
+func (n notation) decimalAt(s string) int {
+	if n.decimal == "" {
+		if r, size := utf8.DecodeRuneInString(s); isDecimalPoint(r) {
+			return size
+		}
+
+		return 0
+	}
+
+	if strings.HasPrefix(s, n.decimal) {
+		return len(n.decimal)
+	}
+
+	return 0
+}
+
+// groupAt returns the length of the group separator at the start of s, or 0 if there is none.
+// Any space stands in for a space separator, so input typed without a no-break space is read.
+func (n notation) groupAt(s string) int {
+	if n.group == "" {
+		return 0
+	}
+
+	if strings.HasPrefix(s, n.group) {
+		return len(n.group)
+	}
+
+	g, _ := utf8.DecodeRuneInString(n.group)
+	if r, size := utf8.DecodeRuneInString(s); unicode.IsSpace(g) && unicode.IsSpace(r) {
+		return size
+	}
+
+	return 0
+}
+
+// validGroup reports whether a digit group of the integer part with size digits and before
+// groups ahead of it is valid: the last group has exactly groupSize digits, and every other
+// group but the first exactly the secondary size of n, which the first has at most.
+func (n notation) validGroup(size, before int, last bool) bool {
+	switch {
+	case last:
+		return size == groupSize
+	case before == 0:
+		return size >= 1 && size <= n.secondary
+	}
+
+	return size == n.secondary
+}
+
+// scan splits s, written in notation n, into a number and an optional unit. A percent sign may
+// precede the number instead of following it. Digit groups must have the sizes of validGroup,
+// so that a group separator mistaken for a decimal separator is reported.
+func scan(s string, n notation) (float64, unit, error) {
+	i := skipSpace(s, 0)
+	if i == len(s) {
+		return 0, unitNone, &SyntaxError{Input: s, Offset: i, Msg: resource.EmptyInputMessage}
+	}
+
+	var num strings.Builder
+
+	if r, size := utf8.DecodeRuneInString(s[i:]); isSign(r) {
+		if r != '+' && r != '＋' {
+			num.WriteByte('-')
+		}
+
+		i += size
+	}
+
+	prefix := unitNone
+	if r, size := utf8.DecodeRuneInString(s[i:]); isPercentSign(r) {
+		prefix = unitPercent
+		i = skipSpace(s, i+size)
+	}
+
+	// group counts the digits of the integer part since the last of groups separators.
+	digits, point, group, groups := 0, false, 0, 0
+
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if d, ok := digit(r); ok {
+			num.WriteRune(d)
+			digits++
+
+			if !point {
+				group++
+			}
+		} else if k := n.decimalAt(s[i:]); k > 0 && !point {
+			if groups > 0 && !n.validGroup(group, groups, true) {
+				return 0, unitNone, &SyntaxError{Input: s, Offset: i, Msg: resource.InvalidGroupingMessage}
+			}
+
+			num.WriteByte('.')
+			point, size = true, k
+		} else if k := n.groupAt(s[i:]); k > 0 && !point && digits > 0 && isDigitAt(s, i+k) {
+			if !n.validGroup(group, groups, false) {
+				return 0, unitNone, &SyntaxError{Input: s, Offset: i, Msg: resource.InvalidGroupingMessage}
+			}
+
+			group, groups, size = 0, groups+1, k
+		} else {
+			break
+		}
+
+		i += size
+	}
+
+	if digits == 0 {
+		return 0, unitNone, &SyntaxError{Input: s, Offset: i, Msg: resource.ExpectedDigitMessage}
+	}
+
+	if !point && groups > 0 && !n.validGroup(group, groups, true) {
+		return 0, unitNone, &SyntaxError{Input: s, Offset: i, Msg: resource.InvalidGroupingMessage}
+	}
+
+	i = skipSpace(s, i)
+
+	u, j, err := scanUnit(s, i)
+	if err != nil {
+		return 0, unitNone, err
+	}
+
+	if prefix != unitNone {
+		if u != unitNone {
+			return 0, unitNone, &SyntaxError{
+				Input: s, Offset: i, Msg: resource.UnexpectedCharacterMessage,
+			}
+		}
+
+		u = prefix
+	}
+
+	if j = skipSpace(s, j); j != len(s) {
+		return 0, unitNone, &SyntaxError{
+			Input: s, Offset: j, Msg: resource.UnexpectedCharacterMessage,
+		}
+	}
+
+	v, err := strconv.ParseFloat(num.String(), 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, unitNone, &SyntaxError{Input: s, Offset: 0, Msg: err.Error()}
+	}
+
+	return v, u, nil
+}
+
+// scanUnit reads the unit starting at offset i of s. It returns the unit and the offset
+// following it.
+func scanUnit(s string, i int) (unit, int, error) {
+	r, n := utf8.DecodeRuneInString(s[i:])
+
+	if isPercentSign(r) {
+		return unitPercent, i + n, nil
+	}
+
+	switch r {
+	case '‰', '؉':
+		return unitPerMille, i + n, nil
+	case '‱', '؊':
+		return unitBasisPoints, i + n, nil
+	}
+
+	j := i
+	for j < len(s) {
+		r, n := utf8.DecodeRuneInString(s[j:])
+		if !unicode.IsLetter(r) {
+			break
+		}
+
+		j += n
+	}
+
+	switch strings.ToLower(s[i:j]) {
+	case "":
+		return unitNone, i, nil
+	case "pct", "percent":
+		return unitPercent, j, nil
+	case "bp", "bps":
+		return unitBasisPoints, j, nil
+	case "ppm":
+		return unitPPM, j, nil
+	}
+
+	return unitNone, i, &SyntaxError{Input: s, Offset: i, Msg: resource.UnknownUnitMessage}
+}
+
+// skipSpace returns the offset of the first non-space rune in s at or after i.
+func skipSpace(s string, i int) int {
+	for i < len(s) {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		i += n
+	}
+
+	return i
+}
+
+// digit returns the ASCII digit for r, which may be an ASCII, full-width, Arabic-Indic or
+// Extended Arabic-Indic digit.
+func digit(r rune) (rune, bool) {
+	for _, zero := range []rune{'0', '０', '٠', '۰'} {
+		if r >= zero && r <= zero+9 {
+			return '0' + r - zero, true
+		}
+	}
+
+	return 0, false
+}
+
+// isDigitAt reports whether s has a digit at offset i.
+func isDigitAt(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	_, ok := digit(r)
+
+	return ok
+}
+
+// isPercentSign reports whether r is a percent sign.
+func isPercentSign(r rune) bool {
+	switch r {
+	case '%', '％', '٪':
+		return true
+	}
+
+	return false
+}
+
+// isSign reports whether r is a plus or minus sign.
+func isSign(r rune) bool {
+	switch r {
+	case '+', '-', '＋', '－', '−':
+		return true
+	}
+
+	return false
+}
+
+// isDecimalPoint reports whether r is a decimal point.
+func isDecimalPoint(r rune) bool {
+	switch r {
+	case '.', '．', '٫':
+		return true
+	}
+
+	return false
+}
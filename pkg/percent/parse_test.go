@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		value percent.Percentage
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "percent sign", in: in{s: "25%"}, want: want{value: 25, err: nil}},
+		{name: "percent sign with space", in: in{s: "12.5 %"}, want: want{value: 12.5, err: nil}},
+		{name: "bare number", in: in{s: "0.25"}, want: want{value: 0.25, err: nil}},
+		{name: "basis points", in: in{s: "250bp"}, want: want{value: 2.5, err: nil}},
+		{name: "basis points plural", in: in{s: "250 bps"}, want: want{value: 2.5, err: nil}},
+		{name: "per mille sign", in: in{s: "3‰"}, want: want{value: 0.3, err: nil}},
+		{name: "per ten thousand sign", in: in{s: "25‱"}, want: want{value: 0.25, err: nil}},
+		{name: "pct word", in: in{s: "15 pct"}, want: want{value: 15, err: nil}},
+		{name: "percent word", in: in{s: "15 Percent"}, want: want{value: 15, err: nil}},
+		{name: "ppm", in: in{s: "7000ppm"}, want: want{value: 0.7, err: nil}},
+		{name: "full-width", in: in{s: "２５％"}, want: want{value: 25, err: nil}},
+		{name: "arabic", in: in{s: "١٢٫٥٪"}, want: want{value: 12.5, err: nil}},
+		{name: "surrounding space", in: in{s: "  +7 % "}, want: want{value: 7, err: nil}},
+		{name: "leading decimal point", in: in{s: ".5%"}, want: want{value: 0.5, err: nil}},
+		{name: "leading percent sign", in: in{s: "%12.5"}, want: want{value: 12.5, err: nil}},
+		{name: "two percent signs", in: in{s: "%12.5%"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "hundred percent", in: in{s: "100%"}, want: want{value: 100, err: nil}},
+		{name: "over hundred", in: in{s: "150%"}, want: want{value: 0, err: percent.ErrOutOfRange}},
+		{name: "negative", in: in{s: "-5%"}, want: want{value: 0, err: percent.ErrOutOfRange}},
+		{name: "empty", in: in{s: "  "}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "sign only", in: in{s: "-%"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "unknown unit", in: in{s: "25 pts"}, want: want{value: 0, err: percent.ErrSyntax}},
+		{name: "trailing text", in: in{s: "25% off"}, want: want{value: 0, err: percent.ErrSyntax}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.Parse(tt.in.s)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Parse() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.in.s, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestParseRatio(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		s string
+	}
+
+	type want struct {
+		value percent.Ratio
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{name: "bare number", in: in{s: "0.25"}, want: want{value: 0.25, err: nil}},
+		{name: "percent sign", in: in{s: "25%"}, want: want{value: 0.25, err: nil}},
+		{name: "basis points", in: in{s: "7bp"}, want: want{value: 0.0007, err: nil}},
+		{name: "per mille", in: in{s: "3‰"}, want: want{value: 0.003, err: nil}},
+		{name: "one", in: in{s: "1"}, want: want{value: 1, err: nil}},
+		{name: "over one", in: in{s: "1.5"}, want: want{value: 0, err: percent.ErrOutOfRange}},
+		{name: "malformed", in: in{s: "0.2.5"}, want: want{value: 0, err: percent.ErrSyntax}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.ParseRatio(tt.in.s)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("ParseRatio() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("ParseRatio(%q) = %v, want %v", tt.in.s, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		offset int
+		msg    string
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{
+			name: "empty input",
+			in:   "",
+			want: want{offset: 0, msg: `pkg percent: invalid syntax: "" at offset 0: empty input`},
+		},
+		{
+			name: "missing digits",
+			in:   " %",
+			want: want{offset: 2, msg: `pkg percent: invalid syntax: " %" at offset 2: expected digit`},
+		},
+		{
+			name: "unknown unit",
+			in:   "25 pts",
+			want: want{offset: 3, msg: `pkg percent: invalid syntax: "25 pts" at offset 3: unknown unit`},
+		},
+		{
+			name: "second decimal point",
+			in:   "1.2.3",
+			want: want{
+				offset: 3, msg: `pkg percent: invalid syntax: "1.2.3" at offset 3: unexpected character`,
+			},
+		},
+		{
+			name: "offset in bytes after multi-byte digits",
+			in:   "２５％x",
+			want: want{
+				offset: 9, msg: `pkg percent: invalid syntax: "２５％x" at offset 9: unexpected character`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			_, err := percent.Parse(tt.in)
+
+			// Assert
+			var got *percent.SyntaxError
+			if !errors.As(err, &got) {
+				t.Fatalf("Parse(%q) error = %v, want *percent.SyntaxError", tt.in, err)
+			}
+			if got.Offset != tt.want.offset {
+				t.Errorf("Parse(%q) offset = %d, want %d", tt.in, got.Offset, tt.want.offset)
+			}
+			if got.Error() != tt.want.msg {
+				t.Errorf("Error() = %q, want %q", got.Error(), tt.want.msg)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []string{
+		"25%",    // percent sign
+		"12.5 %", // percent sign with space
+		"0.25",   // bare number
+		"250bp",  // basis points
+		"3‰",     // per mille
+		"２５％",    // full-width
+		"150%",   // over 100 (should error)
+		"1.2.3",  // malformed (should error)
+	}
+	for _, tc := range testcases {
+		f.Add(tc) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// Arrange
+		// No special arrangement needed
+
+		// Act
+		got, err := percent.Parse(s)
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: Errors are either syntax or range errors
+		// Property 3: If no error, result should be within [0, 100]
+
+		if err != nil {
+			if !errors.Is(err, percent.ErrSyntax) && !errors.Is(err, percent.ErrOutOfRange) {
+				t.Errorf("Parse(%q) returned unexpected error: %v", s, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("Parse(%q) = %v, want 0 on error", s, got)
+			}
+		} else if got < 0 || got > 100 {
+			t.Errorf("Parse(%q) = %v, want value within [0, 100]", s, got)
+		}
+	})
+}
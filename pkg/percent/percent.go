@@ -10,59 +10,138 @@ import (
 )
 
 // Percent returns the percentage of value.
-func Percent[T constraints.Integer | constraints.Float](percent, value T) (float64, error) {
-	if float64(percent) < 0 || float64(percent) > 100 {
-		return 0, resource.ErrOutOfRange
+func Percent[T constraints.Integer | constraints.Float](percent, value T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	return o.percent(float64(percent), float64(value))
+}
+
+// percent returns p percent of v according to o. It is the element operation of Percent and
+// PercentSlice.
+func (o *options) percent(p, v float64) (float64, error) {
+	if err := o.finite("Percent", p, v); err != nil {
+		return 0, err
 	}
 
-	return float64(value) * (float64(percent) / resource.PercentMax), nil
+	p, err := o.bound("Percent", p, resource.PercentMin, resource.PercentMax, p, v)
+	if err != nil {
+		return 0, err
+	}
+
+	return o.result("Percent", v*(p/resource.PercentMax), p, v)
 }
 
 // Of calculates the percentage of the part relative to the total.
-func Of[T constraints.Integer | constraints.Float](part, total T) (float64, error) {
-	if float64(total) == 0 {
-		return 0, resource.ErrDivideByZero
+func Of[T constraints.Integer | constraints.Float](part, total T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	return o.of(float64(part), float64(total))
+}
+
+// of returns the percentage of p in t according to o. It is the element operation of Of,
+// OfSlice and SharesOf.
+func (o *options) of(p, t float64) (float64, error) {
+	if err := o.finite("Of", p, t); err != nil {
+		return 0, err
+	}
+
+	if t == 0 {
+		return 0, newError("Of", ErrDivideByZero, p, t)
 	}
 
-	if float64(part) > float64(total) {
-		return 0, resource.ErrPartGreaterThanTotal
+	if o.policy != Clamp && !o.unbounded() {
+		if err := checkPart("Of", p, t, p, t); err != nil {
+			return 0, err
+		}
 	}
 
-	return float64(part) / float64(total) * resource.PercentMax, nil
+	x := p / t * resource.PercentMax
+	if o.policy == Clamp {
+		x = min(max(x, resource.PercentMin), resource.PercentMax)
+	}
+
+	return o.result("Of", x, p, t)
 }
 
 // Change calculates the percentage change between two values.
-func Change[T constraints.Integer | constraints.Float](oldValue, newValue T) (float64, error) {
-	if float64(oldValue) == 0 {
-		return 0, resource.ErrDivideByZero
+//
+// The change is relative to the magnitude of the old value and has the sign of new-old, so
+// it follows the direction of the move even for negative values: from -100 to -50 is +50%
+// and from -100 to -150 is -50%. A zero old value is rejected with ErrDivideByZero unless
+// WithZeroBaseline or WithEpsilon selects another result. See also LogChange,
+// SymmetricChange and PointChange.
+func Change[T constraints.Integer | constraints.Float](oldValue, newValue T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	return o.change(float64(oldValue), float64(newValue))
+}
+
+// change returns the percentage change from old to n according to o. It is the element
+// operation of Change and ChangeSeries.
+func (o *options) change(old, n float64) (float64, error) {
+	if err := o.finite("Change", old, n); err != nil {
+		return 0, err
+	}
+
+	base := old
+	if old == 0 {
+		if r, ok, err := o.zeroBaseline("Change", n, old, n); ok {
+			return r, err
+		}
+
+		base = o.epsilon
 	}
 
-	return (float64(newValue) - float64(oldValue)) / math.Abs(float64(oldValue)) * resource.PercentMax, nil
+	return o.result("Change", (n-old)/math.Abs(base)*resource.PercentMax, old, n)
 }
 
 // Remain returns the percentage of value that remains after subtracting the percentage.
-func Remain[T constraints.Integer | constraints.Float](percent, value T) (float64, error) {
-	if float64(percent) < 0 || float64(percent) > 100 {
-		return 0, resource.ErrOutOfRange
+func Remain[T constraints.Integer | constraints.Float](percent, value T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	p, v := float64(percent), float64(value)
+	if err := o.finite("Remain", p, v); err != nil {
+		return 0, err
+	}
+
+	p, err := o.bound("Remain", p, resource.PercentMin, resource.PercentMax, p, v)
+	if err != nil {
+		return 0, err
 	}
 
-	return float64(value) * ((resource.PercentMax - float64(percent)) / resource.PercentMax), nil
+	return o.result("Remain", v*((resource.PercentMax-p)/resource.PercentMax), p, v)
 }
 
 // FromRatio returns the percent of ratio.
-func FromRatio[T constraints.Integer | constraints.Float](ratio T) (float64, error) {
-	if float64(ratio) < 0 || float64(ratio) > 1 {
-		return 0, resource.ErrOutOfRange
+func FromRatio[T constraints.Integer | constraints.Float](ratio T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	r := float64(ratio)
+	if err := o.finite("FromRatio", r); err != nil {
+		return 0, err
+	}
+
+	r, err := o.bound("FromRatio", r, resource.RatioMin, resource.RatioMax, r)
+	if err != nil {
+		return 0, err
 	}
 
-	return float64(ratio) * resource.PercentMax, nil
+	return o.result("FromRatio", r*resource.PercentMax, r)
 }
 
 // ToRatio returns the ratio of percent.
-func ToRatio[T constraints.Integer | constraints.Float](percent T) (float64, error) {
-	if float64(percent) < 0 || float64(percent) > 100 {
-		return 0, resource.ErrOutOfRange
+func ToRatio[T constraints.Integer | constraints.Float](percent T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	p := float64(percent)
+	if err := o.finite("ToRatio", p); err != nil {
+		return 0, err
+	}
+
+	p, err := o.bound("ToRatio", p, resource.PercentMin, resource.PercentMax, p)
+	if err != nil {
+		return 0, err
 	}
 
-	return float64(percent) / resource.PercentMax, nil
+	return o.result("ToRatio", p/resource.PercentMax, p)
 }
@@ -4,7 +4,9 @@ package percent_test
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -587,12 +589,14 @@ func FuzzPercent(f *testing.F) {
 		percent float64
 		value   float64
 	}{
-		{0.0, 100.0},   // zero percent
-		{100.0, 100.0}, // hundred percent
-		{50.0, 200.0},  // typical case
-		{25.0, -100.0}, // negative value
-		{-10.0, 100.0}, // negative percent (should error)
-		{150.0, 100.0}, // over 100 percent (should error)
+		{0.0, 100.0},        // zero percent
+		{100.0, 100.0},      // hundred percent
+		{50.0, 200.0},       // typical case
+		{25.0, -100.0},      // negative value
+		{-10.0, 100.0},      // negative percent (should error)
+		{150.0, 100.0},      // over 100 percent (should error)
+		{math.NaN(), 100.0}, // not a number (should error)
+		{50.0, math.Inf(1)}, // infinite value (should error)
 	}
 	for _, tc := range testcases {
 		f.Add(tc.percent, tc.value) // Use f.Add to provide a seed corpus
@@ -609,8 +613,19 @@ func FuzzPercent(f *testing.F) {
 		// Property 1: Function should never panic
 		// Property 2: If percent is out of range [0, 100], should return error
 		// Property 3: If no error, result should be mathematically correct
+		// Property 4: If an input is NaN or infinite, should return ErrNotFinite
+		// Property 5: With AllowNonFinite, should never return ErrNotFinite
 
-		if pct < 0 || pct > 100 {
+		if notFinite(pct, value) {
+			// Non-finite input - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("Percent(%v, %v) error = %v, want ErrNotFinite", pct, value, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("Percent(%v, %v) = %v, want 0 on error", pct, value, got)
+			}
+		} else if pct < 0 || pct > 100 {
 			// Invalid range - should return error
 			if err == nil {
 				t.Errorf("Percent(%v, %v) should return error for out of range percent", pct, value)
@@ -630,6 +645,12 @@ func FuzzPercent(f *testing.F) {
 				t.Errorf("Percent(%v, %v) = %v, want %v", pct, value, got, expected)
 			}
 		}
+
+		// With AllowNonFinite, non-finite values are never rejected
+		_, err = percent.Percent(pct, value, percent.AllowNonFinite())
+		if errors.Is(err, percent.ErrNotFinite) {
+			t.Errorf("Percent(%v, %v) with AllowNonFinite() error = %v", pct, value, err)
+		}
 	})
 }
 
@@ -639,12 +660,14 @@ func FuzzOf(f *testing.F) {
 		part  float64
 		total float64
 	}{
-		{25.0, 100.0},   // typical case
-		{100.0, 100.0},  // part equals total
-		{0.0, 100.0},    // zero part
-		{150.0, 100.0},  // part greater than total (should error)
-		{150.0, 0.0},    // zero total (should error)
-		{-200.0, -50.0}, // negative values
+		{25.0, 100.0},       // typical case
+		{100.0, 100.0},      // part equals total
+		{0.0, 100.0},        // zero part
+		{150.0, 100.0},      // part greater than total (should error)
+		{150.0, 0.0},        // zero total (should error)
+		{-200.0, -50.0},     // negative values
+		{25.0, math.Inf(1)}, // infinite total (should error)
+		{-1e308, 1e-10},     // overflowing result (should error)
 	}
 	for _, tc := range testcases {
 		f.Add(tc.part, tc.total) // Use f.Add to provide a seed corpus
@@ -662,8 +685,19 @@ func FuzzOf(f *testing.F) {
 		// Property 2: If total is zero, should return error
 		// Property 3: If part > total, should return error
 		// Property 4: If no error, result should be mathematically correct
+		// Property 5: If an input or the result is NaN or infinite, should return ErrNotFinite
+		// Property 6: With AllowNonFinite, should never return ErrNotFinite
 
-		if total == 0 {
+		if notFinite(part, total) {
+			// Non-finite input - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("Of(%v, %v) error = %v, want ErrNotFinite", part, total, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("Of(%v, %v) = %v, want 0 on error", part, total, got)
+			}
+		} else if total == 0 {
 			// Zero total - should return error
 			if err == nil {
 				t.Errorf("Of(%v, %v) should return error for zero total", part, total)
@@ -681,17 +715,27 @@ func FuzzOf(f *testing.F) {
 			if got != 0 {
 				t.Errorf("Of(%v, %v) = %v, want 0 on error", part, total, got)
 			}
+		} else if expected := (part / total) * 100.0; notFinite(expected) {
+			// Non-finite result - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("Of(%v, %v) error = %v, want ErrNotFinite", part, total, err)
+			}
 		} else {
 			// Valid input - should not return error
 			if err != nil {
 				t.Errorf("Of(%v, %v) returned unexpected error: %v", part, total, err)
 			}
 			// Verify result is mathematically correct
-			expected := (part / total) * 100.0
 			if math.Abs(got-expected) > 1e-10 {
 				t.Errorf("Of(%v, %v) = %v, want %v", part, total, got, expected)
 			}
 		}
+
+		// With AllowNonFinite, non-finite values are never rejected
+		_, err = percent.Of(part, total, percent.AllowNonFinite())
+		if errors.Is(err, percent.ErrNotFinite) {
+			t.Errorf("Of(%v, %v) with AllowNonFinite() error = %v", part, total, err)
+		}
 	})
 }
 
@@ -701,11 +745,13 @@ func FuzzChange(f *testing.F) {
 		oldValue float64
 		newValue float64
 	}{
-		{25.0, 100.0},   // increase
-		{-50.0, -200.0}, // decrease with negative values
-		{100.0, 100.0},  // no change
-		{0.0, 100.0},    // zero old value (should error)
-		{50.0, 75.0},    // typical increase
+		{25.0, 100.0},     // increase
+		{-50.0, -200.0},   // decrease with negative values
+		{100.0, 100.0},    // no change
+		{0.0, 100.0},      // zero old value (should error)
+		{50.0, 75.0},      // typical increase
+		{math.NaN(), 1.0}, // not a number (should error)
+		{-1e-300, 1e300},  // overflowing result (should error)
 	}
 	for _, tc := range testcases {
 		f.Add(tc.oldValue, tc.newValue) // Use f.Add to provide a seed corpus
@@ -722,8 +768,19 @@ func FuzzChange(f *testing.F) {
 		// Property 1: Function should never panic
 		// Property 2: If oldValue is zero, should return error
 		// Property 3: If no error, result should be mathematically correct
+		// Property 4: If an input or the result is NaN or infinite, should return ErrNotFinite
+		// Property 5: With AllowNonFinite, should never return ErrNotFinite
 
-		if oldValue == 0 {
+		if notFinite(oldValue, newValue) {
+			// Non-finite input - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("Change(%v, %v) error = %v, want ErrNotFinite", oldValue, newValue, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("Change(%v, %v) = %v, want 0 on error", oldValue, newValue, got)
+			}
+		} else if oldValue == 0 {
 			// Zero old value - should return error
 			if err == nil {
 				t.Errorf("Change(%v, %v) should return error for zero old value", oldValue, newValue)
@@ -732,17 +789,27 @@ func FuzzChange(f *testing.F) {
 			if got != 0 {
 				t.Errorf("Change(%v, %v) = %v, want 0 on error", oldValue, newValue, got)
 			}
+		} else if expected := ((newValue - oldValue) / math.Abs(oldValue)) * 100.0; notFinite(expected) {
+			// Non-finite result - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("Change(%v, %v) error = %v, want ErrNotFinite", oldValue, newValue, err)
+			}
 		} else {
 			// Valid input - should not return error
 			if err != nil {
 				t.Errorf("Change(%v, %v) returned unexpected error: %v", oldValue, newValue, err)
 			}
 			// Verify mathematical correctness
-			expected := ((newValue - oldValue) / math.Abs(oldValue)) * 100.0
 			if math.Abs(got-expected) > 1e-10 {
 				t.Errorf("Change(%v, %v) = %v, want %v", oldValue, newValue, got, expected)
 			}
 		}
+
+		// With AllowNonFinite, non-finite values are never rejected
+		_, err = percent.Change(oldValue, newValue, percent.AllowNonFinite())
+		if errors.Is(err, percent.ErrNotFinite) {
+			t.Errorf("Change(%v, %v) with AllowNonFinite() error = %v", oldValue, newValue, err)
+		}
 	})
 }
 
@@ -752,12 +819,14 @@ func FuzzRemain(f *testing.F) {
 		percent float64
 		value   float64
 	}{
-		{25.0, 100.0},  // typical case
-		{0.0, 100.0},   // zero percent
-		{100.0, 50.0},  // hundred percent
-		{50.0, -200.0}, // negative value
-		{-10.0, 100.0}, // negative percent (should error)
-		{150.0, 100.0}, // over 100 percent (should error)
+		{25.0, 100.0},       // typical case
+		{0.0, 100.0},        // zero percent
+		{100.0, 50.0},       // hundred percent
+		{50.0, -200.0},      // negative value
+		{-10.0, 100.0},      // negative percent (should error)
+		{150.0, 100.0},      // over 100 percent (should error)
+		{math.NaN(), 100.0}, // not a number (should error)
+		{50.0, math.Inf(1)}, // infinite value (should error)
 	}
 	for _, tc := range testcases {
 		f.Add(tc.percent, tc.value) // Use f.Add to provide a seed corpus
@@ -774,8 +843,19 @@ func FuzzRemain(f *testing.F) {
 		// Property 1: Function should never panic
 		// Property 2: If percent is out of range [0, 100], should return error
 		// Property 3: If no error, result should be mathematically correct
+		// Property 4: If an input is NaN or infinite, should return ErrNotFinite
+		// Property 5: With AllowNonFinite, should never return ErrNotFinite
 
-		if pct < 0 || pct > 100 {
+		if notFinite(pct, value) {
+			// Non-finite input - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("Remain(%v, %v) error = %v, want ErrNotFinite", pct, value, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("Remain(%v, %v) = %v, want 0 on error", pct, value, got)
+			}
+		} else if pct < 0 || pct > 100 {
 			// Invalid range - should return error
 			if err == nil {
 				t.Errorf("Remain(%v, %v) should return error for out of range percent", pct, value)
@@ -795,17 +875,25 @@ func FuzzRemain(f *testing.F) {
 				t.Errorf("Remain(%v, %v) = %v, want %v", pct, value, got, expected)
 			}
 		}
+
+		// With AllowNonFinite, non-finite values are never rejected
+		_, err = percent.Remain(pct, value, percent.AllowNonFinite())
+		if errors.Is(err, percent.ErrNotFinite) {
+			t.Errorf("Remain(%v, %v) with AllowNonFinite() error = %v", pct, value, err)
+		}
 	})
 }
 
 func FuzzFromRatio(f *testing.F) {
 	// Seed corpus with edge cases using testcases array
 	testcases := []float64{
-		0.25, // typical ratio
-		0.0,  // zero ratio
-		1.0,  // one ratio
-		-0.1, // negative ratio (should error)
-		2.0,  // ratio over 1 (should error)
+		0.25,         // typical ratio
+		0.0,          // zero ratio
+		1.0,          // one ratio
+		-0.1,         // negative ratio (should error)
+		2.0,          // ratio over 1 (should error)
+		math.NaN(),   // not a number (should error)
+		math.Inf(-1), // negative infinity (should error)
 	}
 	for _, tc := range testcases {
 		f.Add(tc) // Use f.Add to provide a seed corpus
@@ -822,8 +910,19 @@ func FuzzFromRatio(f *testing.F) {
 		// Property 1: Function should never panic
 		// Property 2: If ratio is out of range [0, 1], should return error
 		// Property 3: If no error, result should be mathematically correct
+		// Property 4: If an input is NaN or infinite, should return ErrNotFinite
+		// Property 5: With AllowNonFinite, should never return ErrNotFinite
 
-		if ratio < 0 || ratio > 1 {
+		if notFinite(ratio) {
+			// Non-finite input - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("FromRatio(%v) error = %v, want ErrNotFinite", ratio, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("FromRatio(%v) = %v, want 0 on error", ratio, got)
+			}
+		} else if ratio < 0 || ratio > 1 {
 			// Invalid range - should return error
 			if err == nil {
 				t.Errorf("FromRatio(%v) should return error for out of range ratio", ratio)
@@ -843,17 +942,25 @@ func FuzzFromRatio(f *testing.F) {
 				t.Errorf("FromRatio(%v) = %v, want %v", ratio, got, expected)
 			}
 		}
+
+		// With AllowNonFinite, non-finite values are never rejected
+		_, err = percent.FromRatio(ratio, percent.AllowNonFinite())
+		if errors.Is(err, percent.ErrNotFinite) {
+			t.Errorf("FromRatio(%v) with AllowNonFinite() error = %v", ratio, err)
+		}
 	})
 }
 
 func FuzzToRatio(f *testing.F) {
 	// Seed corpus with edge cases using testcases array
 	testcases := []float64{
-		50.0,  // typical percent
-		0.0,   // zero percent
-		100.0, // hundred percent
-		-10.0, // negative percent (should error)
-		150.0, // percent over 100 (should error)
+		50.0,        // typical percent
+		0.0,         // zero percent
+		100.0,       // hundred percent
+		-10.0,       // negative percent (should error)
+		150.0,       // percent over 100 (should error)
+		math.NaN(),  // not a number (should error)
+		math.Inf(1), // infinity (should error)
 	}
 	for _, tc := range testcases {
 		f.Add(tc) // Use f.Add to provide a seed corpus
@@ -870,8 +977,19 @@ func FuzzToRatio(f *testing.F) {
 		// Property 1: Function should never panic
 		// Property 2: If percent is out of range [0, 100], should return error
 		// Property 3: If no error, result should be mathematically correct
+		// Property 4: If an input is NaN or infinite, should return ErrNotFinite
+		// Property 5: With AllowNonFinite, should never return ErrNotFinite
 
-		if pct < 0 || pct > 100 {
+		if notFinite(pct) {
+			// Non-finite input - should return ErrNotFinite
+			if !errors.Is(err, percent.ErrNotFinite) {
+				t.Errorf("ToRatio(%v) error = %v, want ErrNotFinite", pct, err)
+			}
+			// Result should be zero on error
+			if got != 0 {
+				t.Errorf("ToRatio(%v) = %v, want 0 on error", pct, got)
+			}
+		} else if pct < 0 || pct > 100 {
 			// Invalid range - should return error
 			if err == nil {
 				t.Errorf("ToRatio(%v) should return error for out of range percent", pct)
@@ -891,9 +1009,26 @@ func FuzzToRatio(f *testing.F) {
 				t.Errorf("ToRatio(%v) = %v, want %v", pct, got, expected)
 			}
 		}
+
+		// With AllowNonFinite, non-finite values are never rejected
+		_, err = percent.ToRatio(pct, percent.AllowNonFinite())
+		if errors.Is(err, percent.ErrNotFinite) {
+			t.Errorf("ToRatio(%v) with AllowNonFinite() error = %v", pct, err)
+		}
 	})
 }
 
+// notFinite reports whether one of xs is NaN or infinite.
+func notFinite(xs ...float64) bool {
+	for _, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Benchmark tests
 
 var (
@@ -985,6 +1120,74 @@ func BenchmarkOf(b *testing.B) {
 	}
 }
 
+// benchSizes are the lengths of the slices of the slice benchmarks.
+var benchSizes = []int{1 << 10, 1 << 20}
+
+// benchValues returns n values in [1, 100].
+func benchValues(n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i%100 + 1)
+	}
+
+	return x
+}
+
+// BenchmarkPercentSlice compares PercentSlice with a loop of Percent calls over the same values.
+func BenchmarkPercentSlice(b *testing.B) {
+	for _, n := range benchSizes {
+		percents, values, dst := benchValues(n), benchValues(n), make([]float64, n)
+
+		b.Run(fmt.Sprintf("loop/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				for i := range dst {
+					dst[i], benchError = percent.Percent(percents[i], values[i])
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				benchError = percent.PercentSlice(dst, percents, values)
+			}
+		})
+	}
+}
+
+// BenchmarkOfSlice compares OfSlice with a loop of Of calls over the same values.
+func BenchmarkOfSlice(b *testing.B) {
+	for _, n := range benchSizes {
+		parts, totals, dst := benchValues(n), benchValues(n), make([]float64, n)
+
+		b.Run(fmt.Sprintf("loop/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				for i := range dst {
+					dst[i], benchError = percent.Of(parts[i], totals[i])
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				benchError = percent.OfSlice(dst, parts, totals)
+			}
+		})
+	}
+}
+
+// BenchmarkSharesOf measures SharesOf, which sums the parts before it computes the shares.
+func BenchmarkSharesOf(b *testing.B) {
+	for _, n := range benchSizes {
+		parts, dst := benchValues(n), make([]float64, n)
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for b.Loop() {
+				benchError = percent.SharesOf(dst, parts)
+			}
+		})
+	}
+}
+
 func BenchmarkChange(b *testing.B) {
 	benchmarks := []struct {
 		name     string
@@ -1027,6 +1230,27 @@ func BenchmarkChange(b *testing.B) {
 	}
 }
 
+// BenchmarkChangeSeries compares ChangeSeries with a loop of Change calls over the same series.
+func BenchmarkChangeSeries(b *testing.B) {
+	for _, n := range benchSizes {
+		series, dst := benchValues(n+1), make([]float64, n)
+
+		b.Run(fmt.Sprintf("loop/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				for i := range dst {
+					dst[i], benchError = percent.Change(series[i], series[i+1])
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				benchError = percent.ChangeSeries(dst, series)
+			}
+		})
+	}
+}
+
 func BenchmarkRemain(b *testing.B) {
 	benchmarks := []struct {
 		name    string
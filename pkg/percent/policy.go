@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+	"strconv"
+)
+
+// Policy selects how operations treat percentages and ratios outside of their valid range,
+// and how Of treats a part that exceeds its total. Change has no percentage inputs and is not
+// affected.
+type Policy int
+
+const (
+	// Strict rejects a percentage outside of [0, 100] or a ratio outside of [0, 1] with
+	// ErrOutOfRange, and a part greater than its total with ErrPartGreaterThanTotal. It is the
+	// default policy.
+	Strict Policy = iota
+	// Clamp moves a percentage or ratio outside of its range to the nearest bound, and clamps
+	// the result of Of into [0, 100].
+	Clamp
+	// AllowUnbounded accepts percentages above 100 and ratios above 1, and a part greater than
+	// its total, such as 130% of a quota. Negative percentages are still rejected.
+	AllowUnbounded
+	// AllowNegative accepts any percentage or ratio, including negative ones. It implies
+	// AllowUnbounded.
+	AllowNegative
+)
+
+// String returns the name of p, e.g. "allow-unbounded".
+func (p Policy) String() string {
+	switch p {
+	case Strict:
+		return "strict"
+	case Clamp:
+		return "clamp"
+	case AllowUnbounded:
+		return "allow-unbounded"
+	case AllowNegative:
+		return "allow-negative"
+	}
+
+	return "Policy(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Policies returns the policies in the order of their values.
+func Policies() []Policy {
+	return []Policy{Strict, Clamp, AllowUnbounded, AllowNegative}
+}
+
+// LookupPolicy returns the policy named name, as returned by its String method. The second
+// result reports whether a policy was found.
+func LookupPolicy(name string) (Policy, bool) {
+	for _, p := range Policies() {
+		if p.String() == name {
+			return p, true
+		}
+	}
+
+	return Strict, false
+}
+
+// WithPolicy selects the validation policy of an operation. The default is Strict.
+func WithPolicy(p Policy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// unbounded reports whether the policy of o accepts percentages above 100.
+func (o *options) unbounded() bool {
+	return o.policy == AllowUnbounded || o.policy == AllowNegative
+}
+
+// bound returns x checked against [lo, hi] according to the policy of o. It returns x clamped
+// into the range for Clamp, and an *Error wrapping ErrOutOfRange if x is rejected.
+func (o *options) bound(op string, x, lo, hi float64, inputs ...float64) (float64, error) {
+	switch o.policy {
+	case Clamp:
+		return min(max(x, lo), hi), nil
+	case AllowUnbounded:
+		hi = math.Inf(1)
+	case AllowNegative:
+		return x, nil
+	case Strict:
+	}
+
+	return x, checkRange(op, x, lo, hi, inputs...)
+}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call   func(opts ...percent.Option) (float64, error)
+		policy percent.Policy
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	percentOf := func(opts ...percent.Option) (float64, error) { return percent.Percent(150, 200, opts...) }
+	negative := func(opts ...percent.Option) (float64, error) { return percent.Percent(-10, 200, opts...) }
+	of := func(opts ...percent.Option) (float64, error) { return percent.Of(130, 100, opts...) }
+	remain := func(opts ...percent.Option) (float64, error) { return percent.Remain(120, 50, opts...) }
+	fromRatio := func(opts ...percent.Option) (float64, error) { return percent.FromRatio(-0.5, opts...) }
+	toRatio := func(opts ...percent.Option) (float64, error) { return percent.ToRatio(250, opts...) }
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "percent strict",
+			in:   in{call: percentOf, policy: percent.Strict},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "percent clamp",
+			in:   in{call: percentOf, policy: percent.Clamp},
+			want: want{value: 200, err: nil},
+		},
+		{
+			name: "percent allow unbounded",
+			in:   in{call: percentOf, policy: percent.AllowUnbounded},
+			want: want{value: 300, err: nil},
+		},
+		{
+			name: "negative percent clamp",
+			in:   in{call: negative, policy: percent.Clamp},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "negative percent allow unbounded",
+			in:   in{call: negative, policy: percent.AllowUnbounded},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "negative percent allow negative",
+			in:   in{call: negative, policy: percent.AllowNegative},
+			want: want{value: -20, err: nil},
+		},
+		{
+			name: "of strict",
+			in:   in{call: of, policy: percent.Strict},
+			want: want{value: 0, err: percent.ErrPartGreaterThanTotal},
+		},
+		{
+			name: "of clamp",
+			in:   in{call: of, policy: percent.Clamp},
+			want: want{value: 100, err: nil},
+		},
+		{
+			name: "of allow unbounded",
+			in:   in{call: of, policy: percent.AllowUnbounded},
+			want: want{value: 130, err: nil},
+		},
+		{
+			name: "remain allow negative",
+			in:   in{call: remain, policy: percent.AllowNegative},
+			want: want{value: -10, err: nil},
+		},
+		{
+			name: "from ratio clamp",
+			in:   in{call: fromRatio, policy: percent.Clamp},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "from ratio allow negative",
+			in:   in{call: fromRatio, policy: percent.AllowNegative},
+			want: want{value: -50, err: nil},
+		},
+		{
+			name: "to ratio allow unbounded",
+			in:   in{call: toRatio, policy: percent.AllowUnbounded},
+			want: want{value: 2.5, err: nil},
+		},
+		{
+			name: "unknown policy is strict",
+			in:   in{call: of, policy: percent.Policy(42)},
+			want: want{value: 0, err: percent.ErrPartGreaterThanTotal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call(percent.WithPolicy(tt.in.policy))
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("%v: got %v, want %v", tt.in.policy, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestPolicyInt(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call   func(opts ...percent.Option) (int64, error)
+		policy percent.Policy
+	}
+
+	type want struct {
+		value int64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "percent allow unbounded",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.PercentInt(int64(150), 30, opts...)
+				},
+				policy: percent.AllowUnbounded,
+			},
+			want: want{value: 45, err: nil},
+		},
+		{
+			name: "percent allow negative",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.PercentInt(int64(-150), 30, opts...)
+				},
+				policy: percent.AllowNegative,
+			},
+			want: want{value: -45, err: nil},
+		},
+		{
+			name: "remain above 100",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.RemainInt(int64(150), 30, opts...)
+				},
+				policy: percent.AllowUnbounded,
+			},
+			want: want{value: -15, err: nil},
+		},
+		{
+			name: "remain below 0",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.RemainInt(int64(-50), 30, opts...)
+				},
+				policy: percent.AllowNegative,
+			},
+			want: want{value: 45, err: nil},
+		},
+		{
+			name: "remain clamp",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.RemainInt(int64(-50), 30, opts...)
+				},
+				policy: percent.Clamp,
+			},
+			want: want{value: 30, err: nil},
+		},
+		{
+			name: "of clamp",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.OfInt(int64(130), 100, opts...)
+				},
+				policy: percent.Clamp,
+			},
+			want: want{value: 100, err: nil},
+		},
+		{
+			name: "of clamp negative",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.OfInt(int64(-1), 100, opts...)
+				},
+				policy: percent.Clamp,
+			},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "of allow unbounded",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.OfInt(int64(130), 100, opts...)
+				},
+				policy: percent.AllowUnbounded,
+			},
+			want: want{value: 130, err: nil},
+		},
+		{
+			name: "percent strict",
+			in: in{
+				call: func(opts ...percent.Option) (int64, error) {
+					return percent.PercentInt(int64(101), 30, opts...)
+				},
+				policy: percent.Strict,
+			},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call(percent.WithPolicy(tt.in.policy))
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if got != tt.want.value {
+				t.Errorf("%v: got %v, want %v", tt.in.policy, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   percent.Policy
+		want string
+	}{
+		{name: "strict", in: percent.Strict, want: "strict"},
+		{name: "clamp", in: percent.Clamp, want: "clamp"},
+		{name: "allow unbounded", in: percent.AllowUnbounded, want: "allow-unbounded"},
+		{name: "allow negative", in: percent.AllowNegative, want: "allow-negative"},
+		{name: "unknown", in: percent.Policy(9), want: "Policy(9)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got := tt.in.String()
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupPolicy(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		policy percent.Policy
+		ok     bool
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{name: "clamp", in: "clamp", want: want{policy: percent.Clamp, ok: true}},
+		{name: "allow negative", in: "allow-negative", want: want{policy: percent.AllowNegative, ok: true}},
+		{name: "case sensitive", in: "Clamp", want: want{policy: percent.Strict, ok: false}},
+		{name: "unknown", in: "lenient", want: want{policy: percent.Strict, ok: false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, ok := percent.LookupPolicy(tt.in)
+
+			// Assert
+			if got != tt.want.policy || ok != tt.want.ok {
+				t.Errorf("LookupPolicy(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want.policy, tt.want.ok)
+			}
+		})
+	}
+
+	for _, p := range percent.Policies() {
+		if got, ok := percent.LookupPolicy(p.String()); got != p || !ok {
+			t.Errorf("LookupPolicy(%q) = %v, %v, want %v, true", p, got, ok, p)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode selects how a value is rounded to a given number of decimal places.
+type RoundingMode int
+
+const (
+	// HalfAwayFromZero rounds to the nearest value, with ties rounded away from zero, as
+	// math.Round does: 2.5 becomes 3 and -2.5 becomes -3. It is the default mode.
+	HalfAwayFromZero RoundingMode = iota
+	// HalfUp rounds to the nearest value, with ties rounded toward positive infinity: 2.5
+	// becomes 3 and -2.5 becomes -2.
+	HalfUp
+	// HalfEven rounds to the nearest value, with ties rounded to the even neighbor (banker's
+	// rounding): 2.5 becomes 2 and 3.5 becomes 4.
+	HalfEven
+	// Floor rounds toward negative infinity.
+	Floor
+	// Ceil rounds toward positive infinity.
+	Ceil
+	// Truncate rounds toward zero.
+	Truncate
+)
+
+// String returns the name of m, e.g. "half-even".
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfAwayFromZero:
+		return "half-away-from-zero"
+	case HalfUp:
+		return "half-up"
+	case HalfEven:
+		return "half-even"
+	case Floor:
+		return "floor"
+	case Ceil:
+		return "ceil"
+	case Truncate:
+		return "truncate"
+	}
+
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// RoundingModes returns the rounding modes in the order of their values.
+func RoundingModes() []RoundingMode {
+	return []RoundingMode{HalfAwayFromZero, HalfUp, HalfEven, Floor, Ceil, Truncate}
+}
+
+// LookupRoundingMode returns the rounding mode named name, as returned by its String method.
+// The second result reports whether a rounding mode was found.
+func LookupRoundingMode(name string) (RoundingMode, bool) {
+	for _, m := range RoundingModes() {
+		if m.String() == name {
+			return m, true
+		}
+	}
+
+	return HalfAwayFromZero, false
+}
+
+// Round returns x rounded to prec decimal places using mode. A negative prec returns x
+// unchanged.
+//
+// Rounding operates on the shortest decimal representation of x, so a value such as 1.005,
+// which is stored as 1.00499999999999989..., rounds to 1.01 with HalfAwayFromZero. A prec at
+// or beyond the last decimal place of that representation returns x unchanged, however large.
+func Round(x float64, prec int, mode RoundingMode) float64 {
+	if prec < 0 || x == 0 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+
+	s := strconv.FormatFloat(x, 'e', -1, 64)
+	if prec >= decimalPlaces(s) {
+		return x
+	}
+
+	r, _ := new(big.Rat).SetString(s)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)
+	r.Mul(r, new(big.Rat).SetInt(scale))
+
+	q := roundRat(r, mode)
+	f, _ := new(big.Rat).SetFrac(q, scale).Float64()
+
+	return f
+}
+
+// decimalPlaces returns the number of decimal places of s, a finite float in the 'e' format of
+// strconv.FormatFloat such as "-1.25e-03".
+func decimalPlaces(s string) int {
+	e := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[e+1:])
+
+	digits := 0
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		digits = e - dot - 1
+	}
+
+	return digits - exp
+}
+
+// Round returns p rounded to prec decimal places using mode.
+func (p Percentage) Round(prec int, mode RoundingMode) Percentage {
+	return Percentage(Round(float64(p), prec, mode))
+}
+
+// roundRat returns r rounded to an integer using mode.
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+
+	sign := big.NewInt(int64(r.Sign()))
+	half := new(big.Int).Abs(m)
+	half.Lsh(half, 1)
+	tie := half.Cmp(r.Denom())
+
+	var away bool
+
+	switch mode {
+	case HalfAwayFromZero:
+		away = tie >= 0
+	case HalfUp:
+		away = tie > 0 || (tie == 0 && r.Sign() > 0)
+	case HalfEven:
+		away = tie > 0 || (tie == 0 && q.Bit(0) == 1)
+	case Floor:
+		away = r.Sign() < 0
+	case Ceil:
+		away = r.Sign() > 0
+	case Truncate:
+		away = false
+	}
+
+	if away {
+		q.Add(q, sign)
+	}
+
+	return q
+}
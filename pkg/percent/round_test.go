@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestRound(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		x    float64
+		prec int
+	}
+
+	// want holds the expected result per mode, in the order HalfAwayFromZero, HalfUp,
+	// HalfEven, Floor, Ceil, Truncate.
+	type want [6]float64
+
+	modes := []percent.RoundingMode{
+		percent.HalfAwayFromZero,
+		percent.HalfUp,
+		percent.HalfEven,
+		percent.Floor,
+		percent.Ceil,
+		percent.Truncate,
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "positive tie",
+			in:   in{x: 2.5, prec: 0},
+			want: want{3, 3, 2, 2, 3, 2},
+		},
+		{
+			name: "negative tie",
+			in:   in{x: -2.5, prec: 0},
+			want: want{-3, -2, -2, -3, -2, -2},
+		},
+		{
+			name: "odd tie",
+			in:   in{x: 3.5, prec: 0},
+			want: want{4, 4, 4, 3, 4, 3},
+		},
+		{
+			name: "below tie",
+			in:   in{x: 33.333333333333336, prec: 2},
+			want: want{33.33, 33.33, 33.33, 33.33, 33.34, 33.33},
+		},
+		{
+			name: "above tie",
+			in:   in{x: 66.66666666666667, prec: 2},
+			want: want{66.67, 66.67, 66.67, 66.66, 66.67, 66.66},
+		},
+		{
+			name: "decimal tie stored below",
+			in:   in{x: 1.005, prec: 2},
+			want: want{1.01, 1.01, 1, 1, 1.01, 1},
+		},
+		{
+			name: "already exact",
+			in:   in{x: 12.5, prec: 2},
+			want: want{12.5, 12.5, 12.5, 12.5, 12.5, 12.5},
+		},
+		{
+			name: "negative precision",
+			in:   in{x: 1.23456, prec: -1},
+			want: want{1.23456, 1.23456, 1.23456, 1.23456, 1.23456, 1.23456},
+		},
+		{
+			name: "zero",
+			in:   in{x: 0, prec: 2},
+			want: want{0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, mode := range modes {
+				// Arrange
+
+				// Act
+				got := percent.Round(tt.in.x, tt.in.prec, mode)
+
+				// Assert
+				if !cmp.Equal(got, tt.want[i]) {
+					t.Errorf("Round(%v, %d, %v) = %v, want %v", tt.in.x, tt.in.prec, mode, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundLargePrecision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   float64
+	}{
+		{name: "half", in: 1.5},
+		{name: "small", in: -1.25e-300},
+		{name: "large", in: 1e300},
+		{name: "smallest subnormal", in: 5e-324},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			deadline := time.Now().Add(time.Second)
+
+			// Act
+			got := percent.Round(tt.in, 1<<30, percent.HalfEven)
+
+			// Assert
+			if got != tt.in {
+				t.Errorf("Round(%v, 1<<30) = %v, want %v", tt.in, got, tt.in)
+			}
+
+			if time.Now().After(deadline) {
+				t.Errorf("Round(%v, 1<<30) took more than a second", tt.in)
+			}
+		})
+	}
+}
+
+func TestRoundNonFinite(t *testing.T) {
+	t.Parallel()
+
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		// Arrange
+
+		// Act
+		got := percent.Round(x, 2, percent.HalfEven)
+
+		// Assert
+		if !cmp.Equal(got, x, cmp.Comparer(func(a, b float64) bool {
+			return a == b || (math.IsNaN(a) && math.IsNaN(b))
+		})) {
+			t.Errorf("Round(%v) = %v, want %v", x, got, x)
+		}
+	}
+}
+
+func TestRoundingModeString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   percent.RoundingMode
+		want string
+	}{
+		{in: percent.HalfAwayFromZero, want: "half-away-from-zero"},
+		{in: percent.HalfUp, want: "half-up"},
+		{in: percent.HalfEven, want: "half-even"},
+		{in: percent.Floor, want: "floor"},
+		{in: percent.Ceil, want: "ceil"},
+		{in: percent.Truncate, want: "truncate"},
+		{in: percent.RoundingMode(42), want: "RoundingMode(42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got := tt.in.String()
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupRoundingMode(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		mode percent.RoundingMode
+		ok   bool
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want want
+	}{
+		{name: "half even", in: "half-even", want: want{mode: percent.HalfEven, ok: true}},
+		{name: "truncate", in: "truncate", want: want{mode: percent.Truncate, ok: true}},
+		{name: "case sensitive", in: "Floor", want: want{mode: percent.HalfAwayFromZero, ok: false}},
+		{name: "unknown", in: "RoundingMode(42)", want: want{mode: percent.HalfAwayFromZero, ok: false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, ok := percent.LookupRoundingMode(tt.in)
+
+			// Assert
+			if got != tt.want.mode || ok != tt.want.ok {
+				t.Errorf("LookupRoundingMode(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want.mode, tt.want.ok)
+			}
+		})
+	}
+
+	for _, m := range percent.RoundingModes() {
+		if got, ok := percent.LookupRoundingMode(m.String()); got != m || !ok {
+			t.Errorf("LookupRoundingMode(%q) = %v, %v, want %v, true", m, got, ok, m)
+		}
+	}
+}
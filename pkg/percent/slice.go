@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// SliceError reports the elements of a slice operation that failed. The other elements hold
+// their results; a failed element holds zero, as the scalar operation would return. The index
+// and sentinel error of every failed element are recorded, and the full *Error only for the
+// first one, so a SliceError stays small even if most elements fail.
+type SliceError struct {
+	// Op is the name of the slice operation, e.g. "PercentSlice".
+	Op string
+	// Len is the number of elements of the operation.
+	Len int
+	// Indices holds the indices of the failed elements in increasing order.
+	Indices []int
+	// Errs holds the sentinel error of each failed element, in the order of Indices.
+	Errs []error
+	// First is the error of the element at Indices[0].
+	First *Error
+}
+
+// Error implements the error interface.
+func (e *SliceError) Error() string {
+	return fmt.Sprintf(resource.SliceErrorFormat, e.Op, len(e.Indices), e.Len, e.Indices[0], e.First)
+}
+
+// Unwrap returns the error of the first failed element followed by the other sentinel errors
+// of the failed elements, so errors.Is reports whether any element failed with a sentinel.
+func (e *SliceError) Unwrap() []error {
+	errs := []error{e.First}
+
+	for _, err := range e.Errs {
+		if !errors.Is(e.First, err) && !containsError(errs, err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// containsError reports whether errs holds err.
+func containsError(errs []error, err error) bool {
+	for _, e := range errs {
+		if e == err {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add records the failure err of element i of n elements of op, creating the SliceError on
+// the first failure.
+func (e *SliceError) add(op string, i, n int, err error) *SliceError {
+	var pe *Error
+	if !errors.As(err, &pe) {
+		pe = &Error{Op: op, Inputs: nil, Min: 0, Max: 0, Err: err}
+	}
+
+	if e == nil {
+		e = &SliceError{Op: op, Len: n, Indices: nil, Errs: nil, First: pe}
+	}
+
+	e.Indices = append(e.Indices, i)
+	e.Errs = append(e.Errs, pe.Err)
+
+	return e
+}
+
+// err returns e as an error, or nil if no element failed.
+func (e *SliceError) err() error {
+	if e == nil {
+		return nil
+	}
+
+	return e
+}
+
+// lengthError returns an *Error wrapping ErrLengthMismatch for op with the lengths of its
+// slices.
+func lengthError(op string, lengths ...int) error {
+	inputs := make([]float64, len(lengths))
+	for i, n := range lengths {
+		inputs[i] = float64(n)
+	}
+
+	return &Error{Op: op, Inputs: inputs, Min: 0, Max: 0, Err: ErrLengthMismatch}
+}
+
+// PercentSlice stores percents[i] percent of values[i] in dst[i], as Percent does. The
+// options are read once for all elements. With the default precision, no memory is allocated
+// unless an element fails; WithPrecision rounds every result with Round, which allocates.
+//
+// PercentSlice returns an *Error wrapping ErrLengthMismatch if dst, percents and values do not
+// have the same length, and a *SliceError if elements fail.
+func PercentSlice[T constraints.Integer | constraints.Float](
+	dst []float64, percents, values []T, opts ...Option,
+) error {
+	if len(percents) != len(dst) || len(values) != len(dst) {
+		return lengthError("PercentSlice", len(dst), len(percents), len(values))
+	}
+
+	o := newOptions(opts)
+
+	return percentRange(&o, "PercentSlice", dst, percents, values, 0, len(dst)).err()
+}
+
+// OfSlice stores the percentage of parts[i] in totals[i] in dst[i], as Of does. The options
+// are read once for all elements. With the default precision, no memory is allocated unless an
+// element fails; WithPrecision rounds every result with Round, which allocates.
+//
+// OfSlice returns an *Error wrapping ErrLengthMismatch if dst, parts and totals do not have
+// the same length, and a *SliceError if elements fail.
+func OfSlice[T constraints.Integer | constraints.Float](dst []float64, parts, totals []T, opts ...Option) error {
+	if len(parts) != len(dst) || len(totals) != len(dst) {
+		return lengthError("OfSlice", len(dst), len(parts), len(totals))
+	}
+
+	o := newOptions(opts)
+
+	return ofRange(&o, "OfSlice", dst, parts, totals, 0, len(dst)).err()
+}
+
+// ChangeSeries stores the percentage change from series[i] to series[i+1] in dst[i], as
+// Change does, so dst has one element less than series, and none for an empty series. The
+// options are read once for all elements. With the default precision, no memory is allocated
+// unless an element fails; WithPrecision rounds every result with Round, which allocates.
+//
+// ChangeSeries returns an *Error wrapping ErrLengthMismatch if dst does not have one element
+// less than a non-empty series, and a *SliceError if elements fail.
+func ChangeSeries[T constraints.Integer | constraints.Float](dst []float64, series []T, opts ...Option) error {
+	if !seriesLength(len(dst), len(series)) {
+		return lengthError("ChangeSeries", len(dst), len(series))
+	}
+
+	o := newOptions(opts)
+
+	return changeRange(&o, "ChangeSeries", dst, series, 0, len(dst)).err()
+}
+
+// SharesOf stores the percentage of parts[i] in the sum of parts in dst[i], as Of does.
+// Unlike Distribute, the shares are not adjusted to add up to 100 after rounding. The parts
+// are summed as by Summarize. The options are read once for all elements. With the default
+// precision, no memory is allocated unless an element fails; WithPrecision rounds every result
+// with Round, which allocates.
+//
+// SharesOf returns an *Error wrapping ErrLengthMismatch if dst and parts do not have the same
+// length, and a *SliceError if elements fail, e.g. with ErrDivideByZero if the parts sum to
+// zero.
+func SharesOf[T constraints.Integer | constraints.Float](dst []float64, parts []T, opts ...Option) error {
+	if len(parts) != len(dst) {
+		return lengthError("SharesOf", len(dst), len(parts))
+	}
+
+	o := newOptions(opts)
+
+	var sum kahan
+	for lo := 0; lo < len(parts); lo += chunkSize {
+		sum.add(sumRange(parts, lo, min(lo+chunkSize, len(parts))))
+	}
+
+	return sharesRange(&o, "SharesOf", dst, parts, sum.value(), 0, len(dst)).err()
+}
+
+// seriesLength reports whether dst has the length of the changes of a series of n values.
+func seriesLength(dst, n int) bool {
+	return n == dst+1 || n == 0 && dst == 0
+}
+
+// percentRange stores the results of PercentSlice for the elements [lo, hi) in dst and
+// returns the failures of op, if any.
+func percentRange[T constraints.Integer | constraints.Float](
+	o *options, op string, dst []float64, percents, values []T, lo, hi int,
+) *SliceError {
+	var se *SliceError
+
+	percents, values = percents[lo:hi], values[lo:hi]
+	for i := range dst[lo:hi] {
+		p, v := float64(percents[i]), float64(values[i])
+		if x, ok := fastPercent(p, v); ok {
+			dst[lo+i] = o.round(x)
+
+			continue
+		}
+
+		x, err := o.percent(p, v)
+		if err != nil {
+			se = se.add(op, lo+i, len(dst), err)
+		}
+
+		dst[lo+i] = x
+	}
+
+	return se
+}
+
+// ofRange stores the results of OfSlice for the elements [lo, hi) in dst and returns the
+// failures of op, if any.
+func ofRange[T constraints.Integer | constraints.Float](
+	o *options, op string, dst []float64, parts, totals []T, lo, hi int,
+) *SliceError {
+	var se *SliceError
+
+	parts, totals = parts[lo:hi], totals[lo:hi]
+	for i := range dst[lo:hi] {
+		p, t := float64(parts[i]), float64(totals[i])
+		if x, ok := fastOf(p, t); ok {
+			dst[lo+i] = o.round(x)
+
+			continue
+		}
+
+		x, err := o.of(p, t)
+		if err != nil {
+			se = se.add(op, lo+i, len(dst), err)
+		}
+
+		dst[lo+i] = x
+	}
+
+	return se
+}
+
+// changeRange stores the results of ChangeSeries for the elements [lo, hi) in dst and returns
+// the failures of op, if any.
+func changeRange[T constraints.Integer | constraints.Float](
+	o *options, op string, dst []float64, series []T, lo, hi int,
+) *SliceError {
+	if lo == hi {
+		return nil
+	}
+
+	var se *SliceError
+
+	series = series[lo : hi+1]
+	for i := range dst[lo:hi] {
+		old, n := float64(series[i]), float64(series[i+1])
+		if x, ok := fastChange(old, n); ok {
+			dst[lo+i] = o.round(x)
+
+			continue
+		}
+
+		x, err := o.change(old, n)
+		if err != nil {
+			se = se.add(op, lo+i, len(dst), err)
+		}
+
+		dst[lo+i] = x
+	}
+
+	return se
+}
+
+// sharesRange stores the results of SharesOf with the sum total for the elements [lo, hi) in
+// dst and returns the failures of op, if any.
+func sharesRange[T constraints.Integer | constraints.Float](
+	o *options, op string, dst []float64, parts []T, total float64, lo, hi int,
+) *SliceError {
+	var se *SliceError
+
+	parts = parts[lo:hi]
+	for i := range dst[lo:hi] {
+		p := float64(parts[i])
+		if x, ok := fastOf(p, total); ok {
+			dst[lo+i] = o.round(x)
+
+			continue
+		}
+
+		x, err := o.of(p, total)
+		if err != nil {
+			se = se.add(op, lo+i, len(dst), err)
+		}
+
+		dst[lo+i] = x
+	}
+
+	return se
+}
+
+// fastPercent returns p percent of v before rounding and true if p is within [0, 100] and the
+// result is finite, in which case every policy gives that result. The slice operations call
+// the element operation only for the other elements.
+func fastPercent(p, v float64) (float64, bool) {
+	if p < resource.PercentMin || p > resource.PercentMax {
+		return 0, false
+	}
+
+	x := v * (p / resource.PercentMax)
+
+	return x, finite(x)
+}
+
+// fastOf returns the percentage of p in t before rounding and true if 0 <= p <= t and both
+// values are finite and t is positive, in which case every policy gives that result.
+func fastOf(p, t float64) (float64, bool) {
+	if !finite(p) || !finite(t) || p < 0 || p > t || t <= 0 {
+		return 0, false
+	}
+
+	return p / t * resource.PercentMax, true
+}
+
+// fastChange returns the percentage change from old to n before rounding and true if old is
+// not zero, both values are finite and so is the result, in which case every option gives
+// that result.
+func fastChange(old, n float64) (float64, bool) {
+	if old == 0 || !finite(old) || !finite(n) {
+		return 0, false
+	}
+
+	x := (n - old) / math.Abs(old) * resource.PercentMax
+
+	return x, finite(x)
+}
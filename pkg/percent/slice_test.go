@@ -0,0 +1,516 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+// sliceIndices returns the indices of the failed elements reported by err.
+func sliceIndices(err error) []int {
+	var se *percent.SliceError
+	if !errors.As(err, &se) {
+		return nil
+	}
+
+	return se.Indices
+}
+
+func TestPercentSlice(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		dst      []float64
+		percents []float64
+		values   []float64
+		opts     []percent.Option
+	}
+
+	type want struct {
+		value   []float64
+		indices []int
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid inputs",
+			in: in{
+				dst: make([]float64, 3), percents: []float64{25, 0, 12.5}, values: []float64{100, 50, -240},
+				opts: nil,
+			},
+			want: want{value: []float64{25, 0, -30}, indices: nil, err: nil},
+		},
+		{
+			name: "options",
+			in: in{
+				dst: make([]float64, 2), percents: []float64{150, 1}, values: []float64{10, 1.0 / 3},
+				opts: []percent.Option{percent.WithPolicy(percent.Clamp), percent.WithPrecision(3)},
+			},
+			want: want{value: []float64{10, 0.003}, indices: nil, err: nil},
+		},
+		{
+			name: "failed elements",
+			in: in{
+				dst: []float64{9, 9, 9, 9}, percents: []float64{10, 150, 50, math.NaN()}, values: []float64{10, 10, 10, 10},
+				opts: nil,
+			},
+			want: want{value: []float64{1, 0, 5, 0}, indices: []int{1, 3}, err: percent.ErrNotFinite},
+		},
+		{
+			name: "empty",
+			in:   in{dst: nil, percents: nil, values: nil, opts: nil},
+			want: want{value: nil, indices: nil, err: nil},
+		},
+		{
+			name: "length mismatch",
+			in:   in{dst: make([]float64, 2), percents: []float64{1, 2}, values: []float64{1}, opts: nil},
+			want: want{value: []float64{0, 0}, indices: nil, err: percent.ErrLengthMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			err := percent.PercentSlice(tt.in.dst, tt.in.percents, tt.in.values, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("PercentSlice() error = %v, want err %v", err, tt.want.err)
+			}
+
+			if diff := cmp.Diff(tt.want.indices, sliceIndices(err)); diff != "" {
+				t.Errorf("PercentSlice() indices mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want.value, tt.in.dst); diff != "" {
+				t.Errorf("PercentSlice() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOfSlice(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		dst    []float64
+		parts  []int
+		totals []int
+	}
+
+	type want struct {
+		value   []float64
+		indices []int
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid inputs",
+			in:   in{dst: make([]float64, 3), parts: []int{25, 0, -200}, totals: []int{100, 7, -50}},
+			want: want{value: []float64{25, 0, 400}, indices: nil, err: nil},
+		},
+		{
+			name: "failed elements",
+			in:   in{dst: make([]float64, 3), parts: []int{1, 1, 3}, totals: []int{0, 4, 2}},
+			want: want{value: []float64{0, 25, 0}, indices: []int{0, 2}, err: percent.ErrPartGreaterThanTotal},
+		},
+		{
+			name: "length mismatch",
+			in:   in{dst: make([]float64, 1), parts: []int{1, 2}, totals: []int{3, 4}},
+			want: want{value: []float64{0}, indices: nil, err: percent.ErrLengthMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			err := percent.OfSlice(tt.in.dst, tt.in.parts, tt.in.totals)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("OfSlice() error = %v, want err %v", err, tt.want.err)
+			}
+
+			if diff := cmp.Diff(tt.want.indices, sliceIndices(err)); diff != "" {
+				t.Errorf("OfSlice() indices mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want.value, tt.in.dst); diff != "" {
+				t.Errorf("OfSlice() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangeSeries(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		dst    []float64
+		series []float64
+		opts   []percent.Option
+	}
+
+	type want struct {
+		value   []float64
+		indices []int
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "series",
+			in:   in{dst: make([]float64, 3), series: []float64{80, 100, 50, -25}, opts: nil},
+			want: want{value: []float64{25, -50, -150}, indices: nil, err: nil},
+		},
+		{
+			name: "zero baseline",
+			in:   in{dst: make([]float64, 3), series: []float64{10, 0, 5, 5}, opts: nil},
+			want: want{value: []float64{-100, 0, 0}, indices: []int{1}, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "options",
+			in: in{
+				dst: make([]float64, 2), series: []float64{3, 4, 0},
+				opts: []percent.Option{percent.WithPrecision(1), percent.WithRounding(percent.Floor)},
+			},
+			want: want{value: []float64{33.3, -100}, indices: nil, err: nil},
+		},
+		{
+			name: "single value",
+			in:   in{dst: []float64{}, series: []float64{1}, opts: nil},
+			want: want{value: []float64{}, indices: nil, err: nil},
+		},
+		{
+			name: "empty",
+			in:   in{dst: nil, series: nil, opts: nil},
+			want: want{value: nil, indices: nil, err: nil},
+		},
+		{
+			name: "length mismatch",
+			in:   in{dst: make([]float64, 2), series: []float64{1, 2}, opts: nil},
+			want: want{value: []float64{0, 0}, indices: nil, err: percent.ErrLengthMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			err := percent.ChangeSeries(tt.in.dst, tt.in.series, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("ChangeSeries() error = %v, want err %v", err, tt.want.err)
+			}
+
+			if diff := cmp.Diff(tt.want.indices, sliceIndices(err)); diff != "" {
+				t.Errorf("ChangeSeries() indices mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want.value, tt.in.dst); diff != "" {
+				t.Errorf("ChangeSeries() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSharesOf(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		dst   []float64
+		parts []float64
+		opts  []percent.Option
+	}
+
+	type want struct {
+		value   []float64
+		indices []int
+		err     error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "shares",
+			in:   in{dst: make([]float64, 4), parts: []float64{120, 80, 0, 200}, opts: nil},
+			want: want{value: []float64{30, 20, 0, 50}, indices: nil, err: nil},
+		},
+		{
+			name: "rounded shares",
+			in:   in{dst: make([]float64, 3), parts: []float64{1, 1, 1}, opts: []percent.Option{percent.WithPrecision(2)}},
+			want: want{value: []float64{33.33, 33.33, 33.33}, indices: nil, err: nil},
+		},
+		{
+			name: "zero sum",
+			in:   in{dst: make([]float64, 2), parts: []float64{0, 0}, opts: nil},
+			want: want{value: []float64{0, 0}, indices: []int{0, 1}, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "length mismatch",
+			in:   in{dst: make([]float64, 1), parts: []float64{1, 2}, opts: nil},
+			want: want{value: []float64{0}, indices: nil, err: percent.ErrLengthMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+
+			// Act
+			err := percent.SharesOf(tt.in.dst, tt.in.parts, tt.in.opts...)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("SharesOf() error = %v, want err %v", err, tt.want.err)
+			}
+
+			if diff := cmp.Diff(tt.want.indices, sliceIndices(err)); diff != "" {
+				t.Errorf("SharesOf() indices mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want.value, tt.in.dst); diff != "" {
+				t.Errorf("SharesOf() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSliceError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	dst := make([]float64, 4)
+
+	// Act
+	err := percent.OfSlice(dst, []float64{1, 3, 1, 2}, []float64{2, 2, 0, 1})
+
+	// Assert
+	var se *percent.SliceError
+	if !errors.As(err, &se) {
+		t.Fatalf("OfSlice() error = %v, want *SliceError", err)
+	}
+
+	want := "OfSlice: 3 of 4 elements failed, first at index 1: " +
+		"pkg percent: part cannot be greater than total: Of(3, 2) not in [-Inf, 2]"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+
+	if diff := cmp.Diff(
+		[]error{percent.ErrPartGreaterThanTotal, percent.ErrDivideByZero, percent.ErrPartGreaterThanTotal}, se.Errs,
+		cmp.Comparer(func(a, b error) bool { return a == b }),
+	); diff != "" {
+		t.Errorf("Errs mismatch (-want +got):\n%s", diff)
+	}
+
+	var pe *percent.Error
+	if !errors.As(err, &pe) || pe.Op != "Of" || !errors.Is(err, percent.ErrDivideByZero) {
+		t.Errorf("OfSlice() error = %v, want the *Error of Of and ErrDivideByZero", err)
+	}
+}
+
+func TestSliceAllocs(t *testing.T) {
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = float64(i%100 + 1)
+	}
+
+	// With the default precision, the slice operations allocate nothing per element; applying
+	// options allocates once per call.
+	tests := []struct {
+		name string
+		opts []percent.Option
+		want float64
+	}{
+		{
+			name: "default options",
+			opts: nil,
+			want: 0,
+		},
+		{
+			name: "default precision",
+			opts: []percent.Option{percent.WithPolicy(percent.Clamp), percent.WithRounding(percent.Floor)},
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dst := make([]float64, len(values))
+
+			// Act
+			allocs := testing.AllocsPerRun(100, func() {
+				_ = percent.PercentSlice(dst, values, values, tt.opts...)
+				_ = percent.OfSlice(dst, values, values, tt.opts...)
+				_ = percent.ChangeSeries(dst[:len(values)-1], values, tt.opts...)
+				_ = percent.SharesOf(dst, values, tt.opts...)
+			})
+
+			// Assert
+			if allocs != tt.want {
+				t.Errorf("slice operations with %s allocate %v times, want %v", tt.name, allocs, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzPercentSlice(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		percent float64
+		value   float64
+	}{
+		{25, 100},          // typical case
+		{0, 0},             // zeros
+		{150, 10},          // out of range
+		{math.NaN(), 1},    // not finite
+		{-1, math.Inf(-1)}, // negative and infinite
+	}
+	for _, tc := range testcases {
+		f.Add(tc.percent, tc.value) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, p, v float64) {
+		// Arrange
+		dst := []float64{math.Pi}
+
+		// Act
+		err := percent.PercentSlice(dst, []float64{p}, []float64{v})
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: The element equals the result of Percent
+		// Property 3: The element fails if and only if Percent fails, with the same error
+
+		want, wantErr := percent.Percent(p, v)
+		if !cmp.Equal(dst[0], want) {
+			t.Errorf("PercentSlice(%v, %v) = %v, want %v", p, v, dst[0], want)
+		}
+
+		var pe *percent.Error
+		if (err == nil) != (wantErr == nil) || err != nil && (!errors.As(err, &pe) || pe.Error() != wantErr.Error()) {
+			t.Errorf("PercentSlice(%v, %v) error = %v, want %v", p, v, err, wantErr)
+		}
+	})
+}
+
+func FuzzOfSlice(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		part  float64
+		total float64
+	}{
+		{25, 100},          // typical case
+		{1, 0},             // zero total
+		{3, 2},             // part greater than total
+		{math.NaN(), 1},    // not finite part
+		{1, math.Inf(1)},   // not finite total
+		{-1, math.Inf(-1)}, // negative and infinite
+	}
+	for _, tc := range testcases {
+		f.Add(tc.part, tc.total) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, p, v float64) {
+		// Arrange
+		dst := []float64{math.Pi}
+
+		// Act
+		err := percent.OfSlice(dst, []float64{p}, []float64{v})
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: The element equals the result of Of
+		// Property 3: The element fails if and only if Of fails, with the same error
+
+		want, wantErr := percent.Of(p, v)
+		if !cmp.Equal(dst[0], want) {
+			t.Errorf("OfSlice(%v, %v) = %v, want %v", p, v, dst[0], want)
+		}
+
+		var pe *percent.Error
+		if (err == nil) != (wantErr == nil) || err != nil && (!errors.As(err, &pe) || pe.Error() != wantErr.Error()) {
+			t.Errorf("OfSlice(%v, %v) error = %v, want %v", p, v, err, wantErr)
+		}
+	})
+}
+
+func FuzzChangeSeries(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		old float64
+		new float64
+	}{
+		{100, 150},                // typical case
+		{0, 1},                    // zero old value
+		{-50, 25},                 // negative old value
+		{math.NaN(), 1},           // not finite old value
+		{1, math.Inf(1)},          // not finite new value
+		{math.MaxFloat64, -1e308}, // overflow
+	}
+	for _, tc := range testcases {
+		f.Add(tc.old, tc.new) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, old, n float64) {
+		// Arrange
+		dst := []float64{math.Pi}
+
+		// Act
+		err := percent.ChangeSeries(dst, []float64{old, n})
+
+		// Assert
+		// Property 1: Function should never panic
+		// Property 2: The element equals the result of Change
+		// Property 3: The element fails if and only if Change fails, with the same error
+
+		want, wantErr := percent.Change(old, n)
+		if !cmp.Equal(dst[0], want) {
+			t.Errorf("ChangeSeries(%v, %v) = %v, want %v", old, n, dst[0], want)
+		}
+
+		var pe *percent.Error
+		if (err == nil) != (wantErr == nil) || err != nil && (!errors.As(err, &pe) || pe.Error() != wantErr.Error()) {
+			t.Errorf("ChangeSeries(%v, %v) error = %v, want %v", old, n, err, wantErr)
+		}
+	})
+}
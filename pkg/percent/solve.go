@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"math"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// Whole returns the value of which part is percent percent, the inverse of Percent: 20 is
+// 25% of 80.
+//
+// Whole returns an *Error wrapping ErrOutOfRange if percent is rejected by the Policy, and one
+// wrapping ErrDivideByZero if percent is zero.
+func Whole[T constraints.Integer | constraints.Float](percent, part T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	p, v := float64(percent), float64(part)
+	if err := o.finite("Whole", p, v); err != nil {
+		return 0, err
+	}
+
+	p, err := o.bound("Whole", p, resource.PercentMin, resource.PercentMax, p, v)
+	if err != nil {
+		return 0, err
+	}
+
+	if p == 0 {
+		return 0, newError("Whole", ErrDivideByZero, float64(percent), v)
+	}
+
+	return o.result("Whole", v/p*resource.PercentMax, float64(percent), v)
+}
+
+// BeforeIncrease returns the original value that became value after an increase by percent:
+// 100 after a 25% increase was 80.
+//
+// BeforeIncrease returns an *Error wrapping ErrOutOfRange if percent is rejected by the
+// Policy, by default if it is not within [0, 100].
+func BeforeIncrease[T constraints.Integer | constraints.Float](
+	percent, value T, opts ...Option,
+) (float64, error) {
+	o := newOptions(opts)
+
+	p, v := float64(percent), float64(value)
+	if err := o.finite("BeforeIncrease", p, v); err != nil {
+		return 0, err
+	}
+
+	p, err := o.bound("BeforeIncrease", p, resource.PercentMin, resource.PercentMax, p, v)
+	if err != nil {
+		return 0, err
+	}
+
+	// A decrease by 100%, allowed by AllowNegative, leaves nothing to undo.
+	if p == -resource.PercentMax {
+		return 0, newError("BeforeIncrease", ErrDivideByZero, float64(percent), v)
+	}
+
+	return o.result("BeforeIncrease", v/(resource.PercentMax+p)*resource.PercentMax, float64(percent), v)
+}
+
+// BeforeDecrease returns the original value that became value after a decrease by percent,
+// the inverse of Remain: 80 after a 20% decrease was 100.
+//
+// BeforeDecrease returns an *Error wrapping ErrOutOfRange if percent is rejected by the
+// Policy, and one wrapping ErrDivideByZero if percent is 100.
+func BeforeDecrease[T constraints.Integer | constraints.Float](
+	percent, value T, opts ...Option,
+) (float64, error) {
+	o := newOptions(opts)
+
+	p, v := float64(percent), float64(value)
+	if err := o.finite("BeforeDecrease", p, v); err != nil {
+		return 0, err
+	}
+
+	p, err := o.bound("BeforeDecrease", p, resource.PercentMin, resource.PercentMax, p, v)
+	if err != nil {
+		return 0, err
+	}
+
+	if p == resource.PercentMax {
+		return 0, newError("BeforeDecrease", ErrDivideByZero, float64(percent), v)
+	}
+
+	return o.result("BeforeDecrease", v/(resource.PercentMax-p)*resource.PercentMax, float64(percent), v)
+}
+
+// PercentMore returns how many percent x is more than y, which is negative if x is less: 150
+// is 50% more than 100. It is Change with the arguments swapped, so Change(a, b) answers how
+// many percent must be added to a to get b.
+//
+// PercentMore returns an *Error wrapping ErrDivideByZero if y is zero, unless
+// WithZeroBaseline or WithEpsilon selects another result.
+func PercentMore[T constraints.Integer | constraints.Float](x, y T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	a, b := float64(x), float64(y)
+	if err := o.finite("PercentMore", a, b); err != nil {
+		return 0, err
+	}
+
+	base := b
+	if b == 0 {
+		if r, ok, err := o.zeroBaseline("PercentMore", a, a, b); ok {
+			return r, err
+		}
+
+		base = o.epsilon
+	}
+
+	return o.result("PercentMore", (a-b)/math.Abs(base)*resource.PercentMax, a, b)
+}
+
+// Restore returns the increase in percent that restores a value after a decrease by percent:
+// a 20% decrease is undone by a 25% increase.
+//
+// Restore returns an *Error wrapping ErrOutOfRange if percent is rejected by the Policy, and
+// one wrapping ErrDivideByZero if percent is 100, since nothing is left to increase.
+func Restore[T constraints.Integer | constraints.Float](percent T, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+
+	p := float64(percent)
+	if err := o.finite("Restore", p); err != nil {
+		return 0, err
+	}
+
+	p, err := o.bound("Restore", p, resource.PercentMin, resource.PercentMax, p)
+	if err != nil {
+		return 0, err
+	}
+
+	if p == resource.PercentMax {
+		return 0, newError("Restore", ErrDivideByZero, float64(percent))
+	}
+
+	return o.result("Restore", p/(resource.PercentMax-p)*resource.PercentMax, float64(percent))
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call func() (float64, error)
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "whole",
+			in:   in{call: func() (float64, error) { return percent.Whole(25, 20) }},
+			want: want{value: 80, err: nil},
+		},
+		{
+			name: "whole of zero percent",
+			in:   in{call: func() (float64, error) { return percent.Whole(0, 20) }},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "whole out of range",
+			in:   in{call: func() (float64, error) { return percent.Whole(-5, 20) }},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "before increase",
+			in:   in{call: func() (float64, error) { return percent.BeforeIncrease(25, 100) }},
+			want: want{value: 80, err: nil},
+		},
+		{
+			name: "before increase above 100",
+			in: in{call: func() (float64, error) {
+				return percent.BeforeIncrease(150, 100, percent.WithPolicy(percent.AllowUnbounded))
+			}},
+			want: want{value: 40, err: nil},
+		},
+		{
+			name: "before increase rejects above 100 by default",
+			in:   in{call: func() (float64, error) { return percent.BeforeIncrease(150, 100) }},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "before increase by minus 100",
+			in: in{call: func() (float64, error) {
+				return percent.BeforeIncrease(-100, 100, percent.WithPolicy(percent.AllowNegative))
+			}},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "before decrease",
+			in:   in{call: func() (float64, error) { return percent.BeforeDecrease(20, 80) }},
+			want: want{value: 100, err: nil},
+		},
+		{
+			name: "before decrease by 100",
+			in:   in{call: func() (float64, error) { return percent.BeforeDecrease(100, 0) }},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "percent more",
+			in:   in{call: func() (float64, error) { return percent.PercentMore(150, 100) }},
+			want: want{value: 50, err: nil},
+		},
+		{
+			name: "percent less",
+			in:   in{call: func() (float64, error) { return percent.PercentMore(75, 100) }},
+			want: want{value: -25, err: nil},
+		},
+		{
+			name: "percent more than zero",
+			in:   in{call: func() (float64, error) { return percent.PercentMore(1, 0) }},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "percent more than zero is infinite",
+			in: in{call: func() (float64, error) {
+				return percent.PercentMore(1, 0, percent.WithZeroBaseline(percent.ZeroBaselineInf))
+			}},
+			want: want{value: math.Inf(1), err: nil},
+		},
+		{
+			name: "restore",
+			in:   in{call: func() (float64, error) { return percent.Restore(20) }},
+			want: want{value: 25, err: nil},
+		},
+		{
+			name: "restore with precision",
+			in:   in{call: func() (float64, error) { return percent.Restore(10, percent.WithPrecision(2)) }},
+			want: want{value: 11.11, err: nil},
+		},
+		{
+			name: "restore after 100",
+			in:   in{call: func() (float64, error) { return percent.Restore(100) }},
+			want: want{value: 0, err: percent.ErrDivideByZero},
+		},
+		{
+			name: "restore not a number",
+			in:   in{call: func() (float64, error) { return percent.Restore(math.NaN()) }},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call()
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("got %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func FuzzSolve(f *testing.F) {
+	// Seed corpus with edge cases using testcases array
+	testcases := []struct {
+		percent float64
+		value   float64
+	}{
+		{25.0, 100.0},  // typical case
+		{0.0, 100.0},   // zero percent
+		{100.0, 50.0},  // hundred percent
+		{12.5, -200.0}, // negative value
+	}
+	for _, tc := range testcases {
+		f.Add(tc.percent, tc.value) // Use f.Add to provide a seed corpus
+	}
+
+	f.Fuzz(func(t *testing.T, pct float64, value float64) {
+		// Arrange
+		// Inverting loses precision near the limits of float64.
+		if math.IsNaN(pct) || pct < 1e-9 || pct >= 100 || math.IsNaN(value) ||
+			math.Abs(value) > 1e300 || math.Abs(value) < 1e-250 {
+			t.Skip()
+		}
+
+		// Act
+		part, err1 := percent.Percent(pct, value)
+		whole, err2 := percent.Whole(pct, part)
+		remaining, err3 := percent.Remain(pct, value)
+		before, err4 := percent.BeforeDecrease(pct, remaining)
+
+		// Assert
+		// Property 1: Functions should never panic
+		// Property 2: Whole inverts Percent and BeforeDecrease inverts Remain
+
+		if err := errors.Join(err1, err2, err3, err4); err != nil {
+			if errors.Is(err, percent.ErrNotFinite) {
+				return
+			}
+			t.Fatalf("Solve(%v, %v) returned unexpected error: %v", pct, value, err)
+		}
+		if part != 0 && math.Abs(whole-value) > 1e-9*math.Abs(value) {
+			t.Errorf("Whole(%v, Percent(%v, %v)) = %v", pct, pct, value, whole)
+		}
+		if remaining != 0 && math.Abs(before-value) > 1e-9*math.Abs(value) {
+			t.Errorf("BeforeDecrease(%v, Remain(%v, %v)) = %v", pct, pct, value, before)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+)
+
+// The percent types implement sql.Scanner and driver.Valuer. By default a value is stored as a
+// float in the unit of its type; use Stored to select another Storage. Scan accepts floats,
+// integers and text in any notation understood by Parse, and returns an *Error wrapping
+// ErrNotFinite or ErrOutOfRange, as ToRatio does, if the value is not a valid proportion. Use
+// sql.Null for nullable columns.
+
+// Storage selects how a percent value is stored in a database column.
+type Storage int
+
+const (
+	// StoragePercent stores a float in percent, where 25 means 25%.
+	StoragePercent Storage = iota
+	// StorageRatio stores a float as a fraction of one, where 0.25 means 25%.
+	StorageRatio
+	// StorageBasisPoints stores an integer number of basis points, where 2500 means 25%.
+	// Fractions of a basis point are rounded half away from zero.
+	StorageBasisPoints
+	// StorageDecimal stores a string with the shortest decimal form in percent, e.g. "12.5",
+	// for NUMERIC and DECIMAL columns.
+	StorageDecimal
+)
+
+// String returns the name of s, e.g. "basis-points".
+func (s Storage) String() string {
+	switch s {
+	case StoragePercent:
+		return "percent"
+	case StorageRatio:
+		return "ratio"
+	case StorageBasisPoints:
+		return "basis-points"
+	case StorageDecimal:
+		return "decimal"
+	}
+
+	return "Storage(" + strconv.Itoa(int(s)) + ")"
+}
+
+// storeUnit stores a value as a float in the unit of its type.
+const storeUnit Storage = -1
+
+// wire returns the unit stored by s, or w for storeUnit and unknown values.
+func (s Storage) wire(w wire) wire {
+	switch s {
+	case StoragePercent, StorageDecimal:
+		return Percentage(0).wire()
+	case StorageRatio:
+		return Ratio(0).wire()
+	case StorageBasisPoints:
+		return BasisPoints(0).wire()
+	}
+
+	return w
+}
+
+// Stored stores V in a database column as selected by Storage, for use as a query argument or
+// a Scan destination.
+type Stored[T Unit] struct {
+	V       T
+	Storage Storage
+}
+
+// Value implements driver.Valuer.
+func (s Stored[T]) Value() (driver.Value, error) {
+	return s.V.wire().value(float64(s.V), s.Storage)
+}
+
+// Scan implements sql.Scanner.
+func (s *Stored[T]) Scan(src any) error {
+	w := s.V.wire()
+
+	x := float64(s.V)
+	if err := w.scan(src, s.Storage.wire(w), &x); err != nil {
+		return err
+	}
+
+	s.V = T(x)
+
+	return nil
+}
+
+// Value implements driver.Valuer. It stores p as a float in percent.
+func (p Percentage) Value() (driver.Value, error) {
+	return p.wire().value(float64(p), storeUnit)
+}
+
+// Scan implements sql.Scanner.
+func (p *Percentage) Scan(src any) error {
+	return p.wire().scan(src, p.wire(), (*float64)(p))
+}
+
+// Value implements driver.Valuer. It stores r as a float.
+func (r Ratio) Value() (driver.Value, error) {
+	return r.wire().value(float64(r), storeUnit)
+}
+
+// Scan implements sql.Scanner.
+func (r *Ratio) Scan(src any) error {
+	return r.wire().scan(src, r.wire(), (*float64)(r))
+}
+
+// Value implements driver.Valuer. It stores b as a float, which keeps fractions of a basis
+// point. Use StorageBasisPoints to store an integer.
+func (b BasisPoints) Value() (driver.Value, error) {
+	return b.wire().value(float64(b), storeUnit)
+}
+
+// Scan implements sql.Scanner.
+func (b *BasisPoints) Scan(src any) error {
+	return b.wire().scan(src, b.wire(), (*float64)(b))
+}
+
+// Value implements driver.Valuer. It stores m as a float in per mille.
+func (m PerMille) Value() (driver.Value, error) {
+	return m.wire().value(float64(m), storeUnit)
+}
+
+// Scan implements sql.Scanner.
+func (m *PerMille) Scan(src any) error {
+	return m.wire().scan(src, m.wire(), (*float64)(m))
+}
+
+// Value implements driver.Valuer. It stores m as a float in parts per million.
+func (m PPM) Value() (driver.Value, error) {
+	return m.wire().value(float64(m), storeUnit)
+}
+
+// Scan implements sql.Scanner.
+func (m *PPM) Scan(src any) error {
+	return m.wire().scan(src, m.wire(), (*float64)(m))
+}
+
+// value returns x, in the unit of w, as stored by s. Unknown values of s store x as it is,
+// as storeUnit does.
+func (w wire) value(x float64, s Storage) (driver.Value, error) {
+	op := w.name + ".Value"
+	if err := checkFinite(op, x); err != nil {
+		return nil, err
+	}
+
+	p := Percentage(0).wire().convert(x, unitNone, w)
+
+	switch s {
+	case StoragePercent:
+		return p, nil
+	case StorageRatio:
+		return shift(p, -2), nil
+	case StorageBasisPoints:
+		bp := math.Round(shift(p, 2))
+		if math.Abs(bp) >= math.MaxInt64 {
+			return nil, newError(op, ErrOverflow, x)
+		}
+
+		return int64(bp), nil
+	case StorageDecimal:
+		return shortest(p), nil
+	}
+
+	return x, nil
+}
+
+// scan assigns src, a column stored in the unit of from, to dst.
+func (w wire) scan(src any, from wire, dst *float64) error {
+	op := w.name + ".Scan"
+
+	switch v := src.(type) {
+	case float64:
+		return w.store(op, w.convert(v, unitNone, from), dst)
+	case int64:
+		return w.store(op, w.convert(float64(v), unitNone, from), dst)
+	case []byte:
+		return w.parse(op, string(v), from, dst)
+	case string:
+		return w.parse(op, v, from, dst)
+	}
+
+	return fmt.Errorf(resource.UnsupportedTypeErrorFormat, ErrSyntax, op, src)
+}
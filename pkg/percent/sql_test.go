@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestSQL(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		arg  any
+		dest func() (any, func() any)
+	}
+
+	type want struct {
+		stored driver.Value
+		value  any
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "percentage as percent",
+			in:   in{arg: percent.Percentage(12.5), dest: scanInto[percent.Percentage]},
+			want: want{stored: 12.5, value: percent.Percentage(12.5), err: nil},
+		},
+		{
+			name: "ratio as ratio",
+			in:   in{arg: percent.Ratio(0.125), dest: scanInto[percent.Ratio]},
+			want: want{stored: 0.125, value: percent.Ratio(0.125), err: nil},
+		},
+		{
+			name: "basis points keep fractions",
+			in:   in{arg: percent.BasisPoints(12.5), dest: scanInto[percent.BasisPoints]},
+			want: want{stored: 12.5, value: percent.BasisPoints(12.5), err: nil},
+		},
+		{
+			name: "percentage stored as ratio",
+			in: in{
+				arg:  percent.Stored[percent.Percentage]{V: 12.5, Storage: percent.StorageRatio},
+				dest: storedAs[percent.Percentage](percent.StorageRatio),
+			},
+			want: want{stored: 0.125, value: percent.Percentage(12.5), err: nil},
+		},
+		{
+			name: "percentage stored as basis points",
+			in: in{
+				arg:  percent.Stored[percent.Percentage]{V: 12.5, Storage: percent.StorageBasisPoints},
+				dest: storedAs[percent.Percentage](percent.StorageBasisPoints),
+			},
+			want: want{stored: int64(1250), value: percent.Percentage(12.5), err: nil},
+		},
+		{
+			name: "fraction of a basis point is rounded",
+			in: in{
+				arg:  percent.Stored[percent.Percentage]{V: 0.125, Storage: percent.StorageBasisPoints},
+				dest: storedAs[percent.Percentage](percent.StorageBasisPoints),
+			},
+			want: want{stored: int64(13), value: percent.Percentage(0.13), err: nil},
+		},
+		{
+			name: "ratio stored as decimal",
+			in: in{
+				arg:  percent.Stored[percent.Ratio]{V: 0.07, Storage: percent.StorageDecimal},
+				dest: storedAs[percent.Ratio](percent.StorageDecimal),
+			},
+			want: want{stored: "7", value: percent.Ratio(0.07), err: nil},
+		},
+		{
+			name: "ratio stored as percent",
+			in: in{
+				arg:  percent.Stored[percent.Ratio]{V: 0.25, Storage: percent.StoragePercent},
+				dest: storedAs[percent.Ratio](percent.StoragePercent),
+			},
+			want: want{stored: 25.0, value: percent.Ratio(0.25), err: nil},
+		},
+		{
+			name: "percentage from integer",
+			in:   in{arg: int64(30), dest: scanInto[percent.Percentage]},
+			want: want{stored: int64(30), value: percent.Percentage(30), err: nil},
+		},
+		{
+			name: "percentage from text with unit",
+			in:   in{arg: "250bp", dest: scanInto[percent.Percentage]},
+			want: want{stored: "250bp", value: percent.Percentage(2.5), err: nil},
+		},
+		{
+			name: "percentage from numeric bytes",
+			in:   in{arg: []byte("12.50"), dest: scanInto[percent.Percentage]},
+			want: want{stored: []byte("12.50"), value: percent.Percentage(12.5), err: nil},
+		},
+		{
+			name: "percentage out of range",
+			in:   in{arg: 100.5, dest: scanInto[percent.Percentage]},
+			want: want{stored: 100.5, value: percent.Percentage(0), err: percent.ErrOutOfRange},
+		},
+		{
+			name: "ratio out of range",
+			in:   in{arg: int64(2), dest: scanInto[percent.Ratio]},
+			want: want{stored: int64(2), value: percent.Ratio(0), err: percent.ErrOutOfRange},
+		},
+		{
+			name: "basis points out of range",
+			in:   in{arg: int64(-1), dest: storedAs[percent.Percentage](percent.StorageBasisPoints)},
+			want: want{stored: int64(-1), value: percent.Percentage(0), err: percent.ErrOutOfRange},
+		},
+		{
+			name: "malformed text",
+			in:   in{arg: "n/a", dest: scanInto[percent.Percentage]},
+			want: want{stored: "n/a", value: percent.Percentage(0), err: percent.ErrSyntax},
+		},
+		{
+			name: "unsupported type",
+			in:   in{arg: true, dest: scanInto[percent.Percentage]},
+			want: want{stored: true, value: percent.Percentage(0), err: percent.ErrSyntax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db, table := openFake(t)
+			dest, get := tt.in.dest()
+
+			// Act
+			_, errExec := db.ExecContext(t.Context(), "INSERT", tt.in.arg)
+			err := db.QueryRowContext(t.Context(), "SELECT").Scan(dest)
+
+			// Assert
+			if errExec != nil {
+				t.Fatalf("Exec(%v) returned unexpected error: %v", tt.in.arg, errExec)
+			}
+			if got := table.values(); !cmp.Equal(got, []driver.Value{tt.want.stored}) {
+				t.Errorf("stored %v, want %v", got, tt.want.stored)
+			}
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Scan() error = %v, want err %v", err, tt.want.err)
+			}
+			if got := get(); !cmp.Equal(got, tt.want.value) {
+				t.Errorf("Scan() = %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, _ := openFake(t)
+
+	var got sql.Null[percent.Stored[percent.Percentage]]
+	got.V.Storage = percent.StorageBasisPoints
+
+	// Act
+	_, errExec := db.ExecContext(t.Context(), "INSERT", nil)
+	err := db.QueryRowContext(t.Context(), "SELECT").Scan(&got)
+
+	// Assert
+	if errExec != nil || err != nil {
+		t.Fatalf("round trip of NULL returned unexpected error: %v", errors.Join(errExec, err))
+	}
+	if got.Valid {
+		t.Errorf("Scan(NULL) = %v, want invalid", got.V.V)
+	}
+}
+
+func TestSQLValue(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		value driver.Valuer
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "not a number",
+			in:   in{value: percent.Percentage(math.NaN())},
+			want: want{err: percent.ErrNotFinite},
+		},
+		{
+			name: "too many basis points",
+			in:   in{value: percent.Stored[percent.Ratio]{V: 1e300, Storage: percent.StorageBasisPoints}},
+			want: want{err: percent.ErrOverflow},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			_, err := tt.in.value.Value()
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("Value() error = %v, want err %v", err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestStorageString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   percent.Storage
+		want string
+	}{
+		{name: "percent", in: percent.StoragePercent, want: "percent"},
+		{name: "ratio", in: percent.StorageRatio, want: "ratio"},
+		{name: "basis points", in: percent.StorageBasisPoints, want: "basis-points"},
+		{name: "decimal", in: percent.StorageDecimal, want: "decimal"},
+		{name: "unknown", in: percent.Storage(9), want: "Storage(9)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got := tt.in.String()
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// scanInto returns a new T to scan into and a function that reads it.
+func scanInto[T percent.Unit]() (any, func() any) {
+	var v T
+
+	return &v, func() any { return v }
+}
+
+// storedAs returns a function like scanInto that scans T stored as s.
+func storedAs[T percent.Unit](s percent.Storage) func() (any, func() any) {
+	return func() (any, func() any) {
+		v := percent.Stored[T]{V: 0, Storage: s}
+
+		return &v, func() any { return v.V }
+	}
+}
+
+// openFake returns a database backed by an in-memory table with a single column. "INSERT"
+// appends its argument to the table and "SELECT" returns its rows.
+func openFake(t *testing.T) (*sql.DB, *fakeTable) {
+	t.Helper()
+
+	table := &fakeTable{mu: sync.Mutex{}, rows: nil}
+	db := sql.OpenDB(table)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, table
+}
+
+// fakeTable is a driver.Connector, driver.Driver and driver.Conn for an in-memory table.
+type fakeTable struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+func (f *fakeTable) Connect(context.Context) (driver.Conn, error) { return f, nil }
+
+func (f *fakeTable) Driver() driver.Driver { return f }
+
+func (f *fakeTable) Open(string) (driver.Conn, error) { return f, nil }
+
+func (f *fakeTable) Prepare(string) (driver.Stmt, error) { return fakeStmt{table: f}, nil }
+
+func (f *fakeTable) Close() error { return nil }
+
+func (f *fakeTable) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+func (f *fakeTable) values() []driver.Value {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rows
+}
+
+// fakeStmt runs a query against a fakeTable.
+type fakeStmt struct {
+	table *fakeTable
+}
+
+func (s fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+
+	s.table.rows = append(s.table.rows, args...)
+
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.table.values()}, nil
+}
+
+// fakeRows iterates over the rows of a fakeTable.
+type fakeRows struct {
+	rows []driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+
+	return nil
+}
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent
+
+import (
+	"cmp"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sentenz/percent/internal/pkg/resource"
+	"golang.org/x/exp/constraints"
+)
+
+// Percentage is a proportion expressed in percent, where 25 means 25%.
+//
+// The name avoids a clash with the Percent function.
+type Percentage float64
+
+// Ratio is a proportion expressed as a fraction of one, where 0.25 means 25%.
+type Ratio float64
+
+// BasisPoints is a proportion expressed in hundredths of a percent, where 2500 means 25%.
+type BasisPoints float64
+
+// PerMille is a proportion expressed in thousandths, where 250 means 25%.
+type PerMille float64
+
+// PPM is a proportion expressed in parts per million, where 250000 means 25%.
+type PPM float64
+
+// NewPercentage returns percent as a Percentage. It returns an error wrapping ErrNotFinite if
+// percent is NaN or infinite and one wrapping ErrOutOfRange if it is not within [0, 100].
+func NewPercentage[T constraints.Integer | constraints.Float](percent T) (Percentage, error) {
+	p := float64(percent)
+	if err := checkFinite("NewPercentage", p); err != nil {
+		return 0, err
+	}
+
+	if err := checkRange("NewPercentage", p, resource.PercentMin, resource.PercentMax, p); err != nil {
+		return 0, err
+	}
+
+	return Percentage(p), nil
+}
+
+// NewRatio returns ratio as a Ratio. It returns an error wrapping ErrNotFinite if ratio is NaN
+// or infinite and one wrapping ErrOutOfRange if it is not within [0, 1].
+func NewRatio[T constraints.Integer | constraints.Float](ratio T) (Ratio, error) {
+	r := float64(ratio)
+	if err := checkFinite("NewRatio", r); err != nil {
+		return 0, err
+	}
+
+	if err := checkRange("NewRatio", r, resource.RatioMin, resource.RatioMax, r); err != nil {
+		return 0, err
+	}
+
+	return Ratio(r), nil
+}
+
+// NewBasisPoints returns bp as BasisPoints. It returns an error wrapping ErrNotFinite if bp is
+// NaN or infinite and one wrapping ErrOutOfRange if it is not within [0, 10000].
+func NewBasisPoints[T constraints.Integer | constraints.Float](bp T) (BasisPoints, error) {
+	b := float64(bp)
+	if err := checkFinite("NewBasisPoints", b); err != nil {
+		return 0, err
+	}
+
+	if err := checkRange("NewBasisPoints", b, 0, resource.BasisPointsMax, b); err != nil {
+		return 0, err
+	}
+
+	return BasisPoints(b), nil
+}
+
+// NewPerMille returns pm as PerMille. It returns an error wrapping ErrNotFinite if pm is NaN or
+// infinite and one wrapping ErrOutOfRange if it is not within [0, 1000].
+func NewPerMille[T constraints.Integer | constraints.Float](pm T) (PerMille, error) {
+	m := float64(pm)
+	if err := checkFinite("NewPerMille", m); err != nil {
+		return 0, err
+	}
+
+	if err := checkRange("NewPerMille", m, 0, resource.PerMilleMax, m); err != nil {
+		return 0, err
+	}
+
+	return PerMille(m), nil
+}
+
+// NewPPM returns ppm as PPM. It returns an error wrapping ErrNotFinite if ppm is NaN or
+// infinite and one wrapping ErrOutOfRange if it is not within [0, 1000000].
+func NewPPM[T constraints.Integer | constraints.Float](ppm T) (PPM, error) {
+	m := float64(ppm)
+	if err := checkFinite("NewPPM", m); err != nil {
+		return 0, err
+	}
+
+	if err := checkRange("NewPPM", m, 0, resource.PPMMax, m); err != nil {
+		return 0, err
+	}
+
+	return PPM(m), nil
+}
+
+// Ratio returns p as a Ratio.
+func (p Percentage) Ratio() Ratio {
+	return Ratio(shift(float64(p), -2))
+}
+
+// BasisPoints returns p as BasisPoints.
+func (p Percentage) BasisPoints() BasisPoints {
+	return BasisPoints(shift(float64(p), 2))
+}
+
+// PerMille returns p as PerMille.
+func (p Percentage) PerMille() PerMille {
+	return PerMille(shift(float64(p), 1))
+}
+
+// PPM returns p as PPM.
+func (p Percentage) PPM() PPM {
+	return PPM(shift(float64(p), 4))
+}
+
+// Add returns p + q.
+func (p Percentage) Add(q Percentage) Percentage {
+	return p + q
+}
+
+// Sub returns p - q.
+func (p Percentage) Sub(q Percentage) Percentage {
+	return p - q
+}
+
+// Scale returns p multiplied by factor.
+func (p Percentage) Scale(factor float64) Percentage {
+	return Percentage(float64(p) * factor)
+}
+
+// Apply returns p percent of value.
+func (p Percentage) Apply(value float64) float64 {
+	return value * (float64(p) / resource.PercentMax)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether p is less than, equal to or greater than q.
+func (p Percentage) Cmp(q Percentage) int {
+	return cmp.Compare(p, q)
+}
+
+// Percentage returns r as a Percentage.
+func (r Ratio) Percentage() Percentage {
+	return Percentage(shift(float64(r), 2))
+}
+
+// Add returns r + q.
+func (r Ratio) Add(q Ratio) Ratio {
+	return r + q
+}
+
+// Sub returns r - q.
+func (r Ratio) Sub(q Ratio) Ratio {
+	return r - q
+}
+
+// Scale returns r multiplied by factor.
+func (r Ratio) Scale(factor float64) Ratio {
+	return Ratio(float64(r) * factor)
+}
+
+// Apply returns the fraction r of value.
+func (r Ratio) Apply(value float64) float64 {
+	return value * float64(r)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether r is less than, equal to or greater than q.
+func (r Ratio) Cmp(q Ratio) int {
+	return cmp.Compare(r, q)
+}
+
+// Percentage returns b as a Percentage.
+func (b BasisPoints) Percentage() Percentage {
+	return Percentage(shift(float64(b), -2))
+}
+
+// Add returns b + q.
+func (b BasisPoints) Add(q BasisPoints) BasisPoints {
+	return b + q
+}
+
+// Sub returns b - q.
+func (b BasisPoints) Sub(q BasisPoints) BasisPoints {
+	return b - q
+}
+
+// Scale returns b multiplied by factor.
+func (b BasisPoints) Scale(factor float64) BasisPoints {
+	return BasisPoints(float64(b) * factor)
+}
+
+// Apply returns b basis points of value.
+func (b BasisPoints) Apply(value float64) float64 {
+	return value * (float64(b) / resource.BasisPointsMax)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether b is less than, equal to or greater than q.
+func (b BasisPoints) Cmp(q BasisPoints) int {
+	return cmp.Compare(b, q)
+}
+
+// Percentage returns m as a Percentage.
+func (m PerMille) Percentage() Percentage {
+	return Percentage(shift(float64(m), -1))
+}
+
+// Add returns m + q.
+func (m PerMille) Add(q PerMille) PerMille {
+	return m + q
+}
+
+// Sub returns m - q.
+func (m PerMille) Sub(q PerMille) PerMille {
+	return m - q
+}
+
+// Scale returns m multiplied by factor.
+func (m PerMille) Scale(factor float64) PerMille {
+	return PerMille(float64(m) * factor)
+}
+
+// Apply returns m per mille of value.
+func (m PerMille) Apply(value float64) float64 {
+	return value * (float64(m) / resource.PerMilleMax)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or greater than q.
+func (m PerMille) Cmp(q PerMille) int {
+	return cmp.Compare(m, q)
+}
+
+// Percentage returns m as a Percentage.
+func (m PPM) Percentage() Percentage {
+	return Percentage(shift(float64(m), -4))
+}
+
+// Add returns m + q.
+func (m PPM) Add(q PPM) PPM {
+	return m + q
+}
+
+// Sub returns m - q.
+func (m PPM) Sub(q PPM) PPM {
+	return m - q
+}
+
+// Scale returns m multiplied by factor.
+func (m PPM) Scale(factor float64) PPM {
+	return PPM(float64(m) * factor)
+}
+
+// Apply returns m parts per million of value.
+func (m PPM) Apply(value float64) float64 {
+	return value * (float64(m) / resource.PPMMax)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or greater than q.
+func (m PPM) Cmp(q PPM) int {
+	return cmp.Compare(m, q)
+}
+
+// shift moves the decimal point of x by n places. It operates on the shortest decimal
+// representation of x, so conversions such as 0.07 to 7 do not pick up binary rounding error.
+func shift(x float64, n int) float64 {
+	if x == 0 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+
+	s := strconv.FormatFloat(x, 'e', -1, 64)
+	i := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[i+1:])
+	f, _ := strconv.ParseFloat(s[:i+1]+strconv.Itoa(exp+n), 64)
+
+	return f
+}
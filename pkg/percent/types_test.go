@@ -0,0 +1,356 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package percent_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sentenz/percent/pkg/percent"
+)
+
+func TestNewPercentage(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		percent float64
+	}
+
+	type want struct {
+		value percent.Percentage
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "valid input",
+			in:   in{percent: 25},
+			want: want{value: 25, err: nil},
+		},
+		{
+			name: "zero percent",
+			in:   in{percent: 0},
+			want: want{value: 0, err: nil},
+		},
+		{
+			name: "hundred percent",
+			in:   in{percent: 100},
+			want: want{value: 100, err: nil},
+		},
+		{
+			name: "negative percent",
+			in:   in{percent: -1},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "percent over 100",
+			in:   in{percent: 100.5},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "not a number",
+			in:   in{percent: math.NaN()},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+		{
+			name: "infinite percent",
+			in:   in{percent: math.Inf(1)},
+			want: want{value: 0, err: percent.ErrNotFinite},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := percent.NewPercentage(tt.in.percent)
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("NewPercentage() error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("NewPercentage(%+v) = %v, want %v", tt.in, got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestNewUnits(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		call func() (float64, error)
+	}
+
+	type want struct {
+		value float64
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "ratio valid",
+			in: in{call: func() (float64, error) {
+				r, err := percent.NewRatio(0.25)
+				return float64(r), err
+			}},
+			want: want{value: 0.25, err: nil},
+		},
+		{
+			name: "ratio over one",
+			in: in{call: func() (float64, error) {
+				r, err := percent.NewRatio(2)
+				return float64(r), err
+			}},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "basis points valid",
+			in: in{call: func() (float64, error) {
+				b, err := percent.NewBasisPoints(2500)
+				return float64(b), err
+			}},
+			want: want{value: 2500, err: nil},
+		},
+		{
+			name: "basis points over 10000",
+			in: in{call: func() (float64, error) {
+				b, err := percent.NewBasisPoints(10001)
+				return float64(b), err
+			}},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "per mille valid",
+			in: in{call: func() (float64, error) {
+				m, err := percent.NewPerMille(250)
+				return float64(m), err
+			}},
+			want: want{value: 250, err: nil},
+		},
+		{
+			name: "per mille negative",
+			in: in{call: func() (float64, error) {
+				m, err := percent.NewPerMille(-3)
+				return float64(m), err
+			}},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+		{
+			name: "ppm valid",
+			in: in{call: func() (float64, error) {
+				m, err := percent.NewPPM(250000)
+				return float64(m), err
+			}},
+			want: want{value: 250000, err: nil},
+		},
+		{
+			name: "ppm over one million",
+			in: in{call: func() (float64, error) {
+				m, err := percent.NewPPM(1000001)
+				return float64(m), err
+			}},
+			want: want{value: 0, err: percent.ErrOutOfRange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got, err := tt.in.call()
+
+			// Assert
+			if !errors.Is(err, tt.want.err) {
+				t.Errorf("error = %v, want err %v", err, tt.want.err)
+			}
+			if !cmp.Equal(got, tt.want.value) {
+				t.Errorf("got %v, want %v", got, tt.want.value)
+			}
+		})
+	}
+}
+
+func TestPercentageConversions(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		percent percent.Percentage
+	}
+
+	type want struct {
+		ratio       percent.Ratio
+		basisPoints percent.BasisPoints
+		perMille    percent.PerMille
+		ppm         percent.PPM
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "typical value",
+			in:   in{percent: 25},
+			want: want{ratio: 0.25, basisPoints: 2500, perMille: 250, ppm: 250000},
+		},
+		{
+			name: "value without exact binary product",
+			in:   in{percent: 7},
+			want: want{ratio: 0.07, basisPoints: 700, perMille: 70, ppm: 70000},
+		},
+		{
+			name: "fractional value",
+			in:   in{percent: 0.07},
+			want: want{ratio: 0.0007, basisPoints: 7, perMille: 0.7, ppm: 700},
+		},
+		{
+			name: "zero",
+			in:   in{percent: 0},
+			want: want{ratio: 0, basisPoints: 0, perMille: 0, ppm: 0},
+		},
+		{
+			name: "negative value",
+			in:   in{percent: -12.5},
+			want: want{ratio: -0.125, basisPoints: -1250, perMille: -125, ppm: -125000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := tt.in.percent
+
+			// Act
+			got := want{
+				ratio:       p.Ratio(),
+				basisPoints: p.BasisPoints(),
+				perMille:    p.PerMille(),
+				ppm:         p.PPM(),
+			}
+
+			// Assert
+			if !cmp.Equal(got, tt.want, cmp.AllowUnexported(want{})) {
+				t.Errorf("conversions of %v = %+v, want %+v", p, got, tt.want)
+			}
+			if back := got.ratio.Percentage(); back != p {
+				t.Errorf("Ratio.Percentage() = %v, want %v", back, p)
+			}
+			if back := got.basisPoints.Percentage(); back != p {
+				t.Errorf("BasisPoints.Percentage() = %v, want %v", back, p)
+			}
+			if back := got.perMille.Percentage(); back != p {
+				t.Errorf("PerMille.Percentage() = %v, want %v", back, p)
+			}
+			if back := got.ppm.Percentage(); back != p {
+				t.Errorf("PPM.Percentage() = %v, want %v", back, p)
+			}
+		})
+	}
+}
+
+func TestPercentageArithmetic(t *testing.T) {
+	t.Parallel()
+
+	type in struct {
+		p percent.Percentage
+		q percent.Percentage
+	}
+
+	type want struct {
+		add   percent.Percentage
+		sub   percent.Percentage
+		scale percent.Percentage
+		apply float64
+		cmp   int
+	}
+
+	tests := []struct {
+		name string
+		in   in
+		want want
+	}{
+		{
+			name: "greater",
+			in:   in{p: 25, q: 10},
+			want: want{add: 35, sub: 15, scale: 50, apply: 50, cmp: 1},
+		},
+		{
+			name: "less",
+			in:   in{p: 10, q: 25},
+			want: want{add: 35, sub: -15, scale: 20, apply: 20, cmp: -1},
+		},
+		{
+			name: "equal",
+			in:   in{p: 50, q: 50},
+			want: want{add: 100, sub: 0, scale: 100, apply: 100, cmp: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+			got := want{
+				add:   tt.in.p.Add(tt.in.q),
+				sub:   tt.in.p.Sub(tt.in.q),
+				scale: tt.in.p.Scale(2),
+				apply: tt.in.p.Apply(200),
+				cmp:   tt.in.p.Cmp(tt.in.q),
+			}
+
+			// Assert
+			if !cmp.Equal(got, tt.want, cmp.AllowUnexported(want{})) {
+				t.Errorf("arithmetic of %+v = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "ratio", got: percent.Ratio(0.25).Apply(200), want: 50},
+		{name: "basis points", got: percent.BasisPoints(2500).Apply(200), want: 50},
+		{name: "per mille", got: percent.PerMille(250).Apply(200), want: 50},
+		{name: "ppm", got: percent.PPM(250000).Apply(200), want: 50},
+		{name: "ratio add", got: float64(percent.Ratio(0.25).Add(0.5)), want: 0.75},
+		{name: "basis points sub", got: float64(percent.BasisPoints(100).Sub(25)), want: 75},
+		{name: "per mille scale", got: float64(percent.PerMille(10).Scale(3)), want: 30},
+		{name: "ppm cmp", got: float64(percent.PPM(1).Cmp(2)), want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+
+			// Act
+
+			// Assert
+			if !cmp.Equal(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}